@@ -0,0 +1,200 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package goversion
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Constraint 版本约束
+//
+// 支持的格式：
+//   - 比较：>=1.21、>1.21.3、<=1.22、<1.23、=1.22.5，只有次要版本号时只比较次要版本，如 <=1.22 包含所有的 go1.22.x
+//   - 次要版本：~1.22、1.22.x、1.22、go1.22，均表示 go1.22 的任意版本
+//   - 具体版本：1.22.5、go1.22.5、go1.26rc1
+//   - 别名：latest 最新版本（含预览版本），stable 最新的正式版本，oldstable 上一个次要版本的最新正式版本
+//
+// 多个约束使用逗号或者空格分隔，需要同时满足，如 ">=1.21,<1.23"。
+// 除非约束中明确使用了预览版本（如 >=1.26rc1），否则只会匹配正式版本；
+// 变体版本只会匹配使用了同一变体名称的约束，如 ~1.22-acme、>=1.22.3-acme
+type Constraint struct {
+	raw     string
+	alias   string
+	items   []*constraintItem
+	pre     bool
+	variant string
+}
+
+type constraintItem struct {
+	op string
+	v  *Version
+
+	// minorOnly 是否只有次要版本号，如 ~1.22
+	minorOnly bool
+}
+
+const (
+	aliasLatest    = "latest"
+	aliasStable    = "stable"
+	aliasOldStable = "oldstable"
+)
+
+var constraintOpSpace = regexp.MustCompile(`([<>=~])\s+`)
+
+// ParseConstraint 解析版本约束
+func ParseConstraint(str string) (*Constraint, error) {
+	c := &Constraint{
+		raw: str,
+	}
+	switch s := strings.TrimSpace(str); s {
+	case aliasLatest, aliasStable, aliasOldStable:
+		c.alias = s
+		return c, nil
+	}
+
+	str = constraintOpSpace.ReplaceAllString(str, "$1")
+	fields := strings.FieldsFunc(str, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty version constraint %q", str)
+	}
+	for _, f := range fields {
+		item, err := parseConstraintItem(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", str, err)
+		}
+		if item.v.IsPre() {
+			c.pre = true
+		}
+		if len(c.items) > 0 && item.v.Variant != c.variant {
+			return nil, fmt.Errorf("invalid version constraint %q: mixed variants", str)
+		}
+		c.variant = item.v.Variant
+		c.items = append(c.items, item)
+	}
+	return c, nil
+}
+
+func parseConstraintItem(str string) (*constraintItem, error) {
+	var op string
+	for _, o := range []string{">=", "<=", ">", "<", "=", "~"} {
+		if strings.HasPrefix(str, o) {
+			op = o
+			break
+		}
+	}
+	name := strings.TrimPrefix(str[len(op):], "go")
+	if strings.HasSuffix(name, ".x") || strings.HasSuffix(name, ".*") {
+		if len(op) != 0 {
+			return nil, fmt.Errorf("unexpected %q", str)
+		}
+		op, name = "~", name[:len(name)-2]
+	}
+	v, err := Parse("go" + name)
+	if err != nil {
+		return nil, err
+	}
+	if v.IsTip() {
+		return nil, fmt.Errorf("unexpected %q", str)
+	}
+	item := &constraintItem{
+		op:        op,
+		v:         v,
+		minorOnly: v.Upstream().Raw == v.Lang(),
+	}
+	if len(op) == 0 {
+		// 如 1.22，表示 go1.22 的任意版本
+		if item.minorOnly {
+			item.op = "~"
+		} else {
+			item.op = "="
+		}
+	}
+	return item, nil
+}
+
+// String 原始的约束内容
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+func (ci *constraintItem) match(v *Version) bool {
+	n := v.Compare(ci.v)
+	if ci.minorOnly {
+		// 只有次要版本号时只比较次要版本，go1.20 是发布版本、go1.21 是语言版本，直接比较时结果会不同，
+		// 如 <=1.22 应包含所有的 go1.22.x，>1.21 不应包含 go1.21.0
+		n = cmp.Compare(v.Minor, ci.v.Minor)
+	}
+	switch ci.op {
+	case ">=":
+		return n >= 0
+	case ">":
+		return n > 0
+	case "<=":
+		return n <= 0
+	case "<":
+		return n < 0
+	case "~":
+		return v.Minor == ci.v.Minor && n >= 0
+	default:
+		return n == 0
+	}
+}
+
+// Match 版本是否满足约束，别名（如 latest）需要使用 Resolve
+func (c *Constraint) Match(v *Version) bool {
+	if v.IsTip() || len(c.alias) != 0 {
+		return false
+	}
+	if !c.pre && v.IsPre() {
+		return false
+	}
+	if v.Variant != c.variant {
+		return false
+	}
+	for _, item := range c.items {
+		if !item.match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Resolve 从版本列表中找出满足约束的最新版本，若没有会返回 nil
+func (c *Constraint) Resolve(list []*Version) *Version {
+	var match func(v *Version) bool
+	switch c.alias {
+	case aliasLatest:
+		match = func(v *Version) bool {
+			return !v.IsTip() && !v.IsVariant()
+		}
+	case aliasStable:
+		match = func(v *Version) bool {
+			return !v.IsTip() && !v.IsPre() && !v.IsVariant()
+		}
+	case aliasOldStable:
+		stable := (&Constraint{alias: aliasStable}).Resolve(list)
+		if stable == nil {
+			return nil
+		}
+		match = func(v *Version) bool {
+			return !v.IsTip() && !v.IsPre() && !v.IsVariant() && v.Minor < stable.Minor
+		}
+	default:
+		match = c.Match
+	}
+	var result *Version
+	for _, v := range list {
+		if match(v) && (result == nil || v.Compare(result) > 0) {
+			result = v
+		}
+	}
+	return result
+}
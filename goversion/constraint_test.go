@@ -0,0 +1,126 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package goversion
+
+import (
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestConstraint_Resolve(t *testing.T) {
+	var list []*Version
+	for _, name := range []string{
+		"go1.19", "go1.19.13",
+		"go1.20rc1", "go1.20", "go1.20.1", "go1.20.14",
+		"go1.21rc2", "go1.21.0", "go1.21.9", "go1.21.10",
+		"go1.22.0", "go1.22.5", "go1.22.5-acme", "go1.22.6-acme",
+		"go1.23rc1",
+		"gotip",
+	} {
+		list = append(list, MustParse(name))
+	}
+
+	tests := []struct {
+		constraint string
+		want       string
+		wantErr    bool
+	}{
+		{constraint: ">=1.21", want: "go1.22.5"},
+		{constraint: "<1.21", want: "go1.20.14"},
+		{constraint: "<=1.21.9", want: "go1.21.9"},
+		{constraint: ">1.21.9, <1.22", want: "go1.21.10"},
+		{constraint: ">= 1.20 <1.21", want: "go1.20.14"},
+		{constraint: "~1.21", want: "go1.21.10"},
+		{constraint: "~1.20.1", want: "go1.20.14"},
+		{constraint: "1.20.x", want: "go1.20.14"},
+		{constraint: "1.19", want: "go1.19.13"},
+		{constraint: "=1.20.0", want: "go1.20"},
+		{constraint: "go1.21.0", want: "go1.21.0"},
+		{constraint: ">=1.23rc1", want: "go1.23rc1"},
+		{constraint: "~1.23", want: ""},
+		{constraint: "latest", want: "go1.23rc1"},
+		{constraint: "stable", want: "go1.22.5"},
+		{constraint: "oldstable", want: "go1.21.10"},
+		{constraint: "~1.22", want: "go1.22.5"},
+		{constraint: "~1.22-acme", want: "go1.22.6-acme"},
+		{constraint: "1.22.x-acme", wantErr: true},
+		{constraint: "<1.22.6-acme", want: "go1.22.5-acme"},
+		{constraint: "go1.22.5-acme", want: "go1.22.5-acme"},
+		{constraint: ">=1.21-acme", want: "go1.22.6-acme"},
+		{constraint: ">=1.21,<1.23-acme", wantErr: true},
+		// 只有次要版本号时只比较次要版本，go1.20 之前和 go1.21 之后的命名方式不同
+		{constraint: "<=1.22", want: "go1.22.5"},
+		{constraint: "<=1.21", want: "go1.21.10"},
+		{constraint: "<=1.20", want: "go1.20.14"},
+		{constraint: "<=1.19", want: "go1.19.13"},
+		{constraint: ">1.21, <1.23", want: "go1.22.5"},
+		{constraint: ">1.20, <1.22", want: "go1.21.10"},
+		{constraint: ">1.19, <1.21", want: "go1.20.14"},
+		{constraint: ">1.22", want: ""},
+		{constraint: "<1.22", want: "go1.21.10"},
+		{constraint: "<1.20", want: "go1.19.13"},
+		{constraint: ">=1.22", want: "go1.22.5"},
+		{constraint: ">=1.20, <=1.20", want: "go1.20.14"},
+		{constraint: ">=1.21, <=1.21", want: "go1.21.10"},
+		{constraint: "=1.21", want: "go1.21.10"},
+		{constraint: ">=x1.21", wantErr: true},
+		{constraint: ">=1.21.x", wantErr: true},
+		{constraint: " , ", wantErr: true},
+		{constraint: ">=gotip", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if tt.wantErr {
+				fst.Error(t, err)
+				return
+			}
+			fst.NoError(t, err)
+			var got string
+			if v := c.Resolve(list); v != nil {
+				got = v.Raw
+			}
+			fst.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConstraint_Match(t *testing.T) {
+	names := []string{
+		"go1.19.13", "go1.20", "go1.20.14", "go1.21rc2", "go1.21.0", "go1.21.10",
+		"go1.22.0", "go1.22.5", "go1.23.1",
+	}
+	// 只有次要版本号时只比较次要版本，go1.20（发布版本）和 go1.21（语言版本）两侧的结果一致
+	tests := map[string][]string{
+		"<=1.20": {"go1.19.13", "go1.20", "go1.20.14"},
+		"<1.20":  {"go1.19.13"},
+		">1.20":  {"go1.21.0", "go1.21.10", "go1.22.0", "go1.22.5", "go1.23.1"},
+		">=1.20": {"go1.20", "go1.20.14", "go1.21.0", "go1.21.10", "go1.22.0", "go1.22.5", "go1.23.1"},
+		"<=1.21": {"go1.19.13", "go1.20", "go1.20.14", "go1.21.0", "go1.21.10"},
+		"<1.21":  {"go1.19.13", "go1.20", "go1.20.14"},
+		">1.21":  {"go1.22.0", "go1.22.5", "go1.23.1"},
+		">=1.21": {"go1.21.0", "go1.21.10", "go1.22.0", "go1.22.5", "go1.23.1"},
+		"<=1.22": {"go1.19.13", "go1.20", "go1.20.14", "go1.21.0", "go1.21.10", "go1.22.0", "go1.22.5"},
+		">1.22":  {"go1.23.1"},
+		"=1.20":  {"go1.20", "go1.20.14"},
+		// 有修订版本号时依然按完整的版本比较
+		"<=1.20.0": {"go1.19.13", "go1.20"},
+		">1.21.0":  {"go1.21.10", "go1.22.0", "go1.22.5", "go1.23.1"},
+	}
+	for constraint, want := range tests {
+		t.Run(constraint, func(t *testing.T) {
+			c, err := ParseConstraint(constraint)
+			fst.NoError(t, err)
+			var got []string
+			for _, name := range names {
+				if c.Match(MustParse(name)) {
+					got = append(got, name)
+				}
+			}
+			fst.Equal(t, want, got)
+		})
+	}
+}
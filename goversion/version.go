@@ -0,0 +1,275 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+// Package goversion 解析、比较 Go 的发布版本号，
+// 并将其转换为下载文件名、go.mod 中的 go 指令和 GOTOOLCHAIN 的值
+//
+// Go 的发布版本有两种命名方式：
+//   - go1.21 之前，次要版本的首个正式版本没有修订号，如 go1.20，之后为 go1.20.1、go1.20.2
+//   - go1.21 开始，首个正式版本为 go1.21.0，而 go1.21 表示语言版本，不是一个发布版本
+//
+// 版本号还可以有变体后缀，如 go1.22.5-acme，表示基于 go1.22.5 打了补丁后构建的版本
+package goversion
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ThreePartMinor 从此次要版本开始，首个正式版本使用 3 位版本号，如 go1.21.0
+const ThreePartMinor = 21
+
+// Tip 开发版本的名称
+const Tip = "gotip"
+
+// Version 一个 Go 版本
+type Version struct {
+	// Raw 原始的版本号，如 go1.10、go1.9rc2、go1.18beta1、go1.21.0、gotip
+	Raw string
+
+	// Minor 次要版本号，如 go1.22.5 为 22
+	Minor int
+
+	// Patch 修订版本号，如 go1.22.5 为 5，go1.20 为 0
+	// 预览版本、go1.21 开始的语言版本（如 go1.21）以及 gotip 为 -1
+	Patch int
+
+	// Pre 预览版本的类型：beta 或 rc，正式版本为空
+	Pre string
+
+	// PreNum 预览版本的序号，如 go1.26rc2 为 2
+	PreNum int
+
+	// Variant 变体名称，如 go1.22.5-acme 为 acme，上游的发布版本为空
+	Variant string
+}
+
+var versionReg = regexp.MustCompile(`^go1\.(0|[1-9]\d*)(?:\.(0|[1-9]\d*)|rc([1-9]\d*)|beta([1-9]\d*))?(?:-([a-zA-Z][a-zA-Z0-9_-]*))?$`)
+
+// Parse 解析版本号，如 go1.10、go1.10.1、go1.9rc2、go1.18beta1、go1.21、go1.21.0、gotip，
+// 以及变体版本，如 go1.22.5-acme
+func Parse(version string) (*Version, error) {
+	if version == Tip {
+		return &Version{Raw: Tip, Patch: -1}, nil
+	}
+	matches := versionReg.FindStringSubmatch(version)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("not go version: %q", version)
+	}
+	// go1.10    	-> ["go1.10" "10" "" "" ""]
+	// go1.10.11 	-> ["go1.10.11" "10" "11" "" ""]
+	// go1.9rc2  	-> ["go1.9rc2" "9" "" "2" ""]
+	// go1.18beta2	-> ["go1.18beta2" "18" "" "" "2" ""]
+	// go1.22.5-acme -> ["go1.22.5-acme" "22" "5" "" "" "acme"]
+	v := &Version{
+		Raw:     version,
+		Patch:   -1,
+		Variant: matches[5],
+	}
+	var err error
+	if v.Minor, err = strconv.Atoi(matches[1]); err != nil {
+		return nil, fmt.Errorf("invalid go version %q: %w", version, err)
+	}
+	switch {
+	case len(matches[2]) != 0:
+		v.Patch, err = strconv.Atoi(matches[2])
+	case len(matches[3]) != 0:
+		v.Pre = "rc"
+		v.PreNum, err = strconv.Atoi(matches[3])
+	case len(matches[4]) != 0:
+		v.Pre = "beta"
+		v.PreNum, err = strconv.Atoi(matches[4])
+	case v.Minor < ThreePartMinor:
+		// go1.20 是 go1.20 的首个正式版本
+		v.Patch = 0
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid go version %q: %w", version, err)
+	}
+	return v, nil
+}
+
+// MustParse 解析版本号，若失败会 panic
+func MustParse(version string) *Version {
+	v, err := Parse(version)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String 原始的版本号
+func (v *Version) String() string {
+	return v.Raw
+}
+
+// IsTip 是否开发版本 gotip
+func (v *Version) IsTip() bool {
+	return v.Raw == Tip
+}
+
+// IsPre 是否预览版本，即 beta、rc
+func (v *Version) IsPre() bool {
+	return len(v.Pre) != 0
+}
+
+// IsVariant 是否变体版本，如 go1.22.5-acme
+func (v *Version) IsVariant() bool {
+	return len(v.Variant) != 0
+}
+
+// Upstream 变体版本对应的上游版本，如 go1.22.5-acme 为 go1.22.5，非变体版本返回自身
+func (v *Version) Upstream() *Version {
+	if !v.IsVariant() {
+		return v
+	}
+	u := *v
+	u.Raw = v.Raw[:len(v.Raw)-len(v.Variant)-1]
+	u.Variant = ""
+	return &u
+}
+
+// IsLanguage 是否 go1.21 开始的语言版本，如 go1.21，其不是一个发布版本
+func (v *Version) IsLanguage() bool {
+	return !v.IsTip() && v.Minor >= ThreePartMinor && v.Patch < 0 && !v.IsPre()
+}
+
+// Lang 语言版本，即归一化的二位版本号，如 go1.22.5 为 go1.22
+func (v *Version) Lang() string {
+	if v.IsTip() {
+		return Tip
+	}
+	return fmt.Sprintf("go1.%d", v.Minor)
+}
+
+// Name 发布版本的名称，如 go1.20、go1.20.1、go1.21.0、go1.21rc2、gotip、go1.22.5-acme
+// 其中 go1.20.0 会转换为 go1.20，语言版本 go1.21 会转换为其首个正式版本 go1.21.0
+func (v *Version) Name() string {
+	switch {
+	case v.IsTip():
+		return Tip
+	case v.IsPre():
+		return fmt.Sprintf("go1.%d%s%d", v.Minor, v.Pre, v.PreNum) + v.variantSuffix()
+	case v.Minor < ThreePartMinor && v.Patch <= 0:
+		return v.Lang() + v.variantSuffix()
+	default:
+		return fmt.Sprintf("go1.%d.%d", v.Minor, max(v.Patch, 0)) + v.variantSuffix()
+	}
+}
+
+// Formatted 3 位版本号，如 go1.20 为 go1.20.0，预览版本和 gotip 同 Name
+func (v *Version) Formatted() string {
+	if v.IsTip() || v.IsPre() {
+		return v.Name()
+	}
+	return fmt.Sprintf("go1.%d.%d", v.Minor, max(v.Patch, 0)) + v.variantSuffix()
+}
+
+// variantSuffix 变体版本的后缀，如 -acme
+func (v *Version) variantSuffix() string {
+	if !v.IsVariant() {
+		return ""
+	}
+	return "-" + v.Variant
+}
+
+// kind 用于排序：语言版本 < beta < rc < 正式版本
+func (v *Version) kind() int {
+	switch {
+	case v.Pre == "beta":
+		return 1
+	case v.Pre == "rc":
+		return 2
+	case v.IsLanguage():
+		return 0
+	default:
+		return 3
+	}
+}
+
+// Compare 和另外一个版本比较，v < b 时返回 -1，v == b 时返回 0，v > b 时返回 1
+//
+// 如 go1.20rc1 < go1.20 == go1.20.0 < go1.20.1，go1.21 < go1.21rc1 < go1.21.0，
+// gotip 比其他版本都大。变体版本排在其上游版本之后，如 go1.22.5 < go1.22.5-acme < go1.22.6
+func (v *Version) Compare(b *Version) int {
+	if v.IsTip() || b.IsTip() {
+		return cmp.Compare(boolInt(v.IsTip()), boolInt(b.IsTip()))
+	}
+	if n := cmp.Compare(v.Minor, b.Minor); n != 0 {
+		return n
+	}
+	if n := cmp.Compare(v.kind(), b.kind()); n != 0 {
+		return n
+	}
+	if v.IsPre() {
+		if n := cmp.Compare(v.PreNum, b.PreNum); n != 0 {
+			return n
+		}
+	} else if n := cmp.Compare(max(v.Patch, 0), max(b.Patch, 0)); n != 0 {
+		return n
+	}
+	return cmp.Compare(v.Variant, b.Variant)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Compare 比较两个版本，a < b 时返回 -1，a == b 时返回 0，a > b 时返回 1
+func Compare(a, b *Version) int {
+	return a.Compare(b)
+}
+
+// Less a 是否比 b 旧
+func Less(a, b *Version) bool {
+	return a.Compare(b) < 0
+}
+
+// ArchiveName 官方二进制文件的名称，如 go1.22.5.linux-amd64.tar.gz
+// 变体版本没有官方的打包文件，需要使用 Upstream 的
+func (v *Version) ArchiveName(goos string, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	if goos == "linux" && goarch == "arm" {
+		goarch = "armv6l"
+	}
+	return v.Name() + "." + goos + "-" + goarch + ext
+}
+
+// SourceArchiveName 官方源码打包文件的名称，如 go1.22.5.src.tar.gz
+func (v *Version) SourceArchiveName() string {
+	return v.Name() + ".src.tar.gz"
+}
+
+// GoModLine go.mod 文件中的 go 指令，如 go 1.20、go 1.22.5、go 1.23rc1
+// go1.21 之前的版本只有 2 位版本号，如 go1.20.3 为 go 1.20
+func (v *Version) GoModLine() string {
+	if v.IsTip() {
+		return ""
+	}
+	if v.Minor < ThreePartMinor || v.IsLanguage() {
+		return "go " + v.Lang()[2:]
+	}
+	return "go " + v.Upstream().Name()[2:]
+}
+
+// ErrNoToolchain 版本不支持作为 GOTOOLCHAIN 的值
+var ErrNoToolchain = errors.New("toolchain switching requires go1.21 or later")
+
+// Toolchain GOTOOLCHAIN 环境变量或 go.mod 中 toolchain 指令的值，如 go1.22.5、go1.23rc1
+// go1.21 之前的版本和 gotip 不支持，会返回 ErrNoToolchain
+func (v *Version) Toolchain() (string, error) {
+	if v.IsTip() || v.Minor < ThreePartMinor {
+		return "", fmt.Errorf("%s: %w", v.Raw, ErrNoToolchain)
+	}
+	return v.Name(), nil
+}
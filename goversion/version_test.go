@@ -0,0 +1,252 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package goversion
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestParse_valid(t *testing.T) {
+	testsOk := []string{
+		"go1.1", "go1.10", "go1.10.1", "go1.10.11", "go1.10.11",
+		"go1.9rc1", "go1.9rc2",
+		"go1.8beta1",
+		"go1.18beta2",
+		"go1.21", "go1.21.0", "go1.21rc2", "gotip",
+		"go1.22.5-acme", "go1.22-acme", "go1.23rc1-acme_2", "go1.20-Acme-v2",
+	}
+	for _, tt := range testsOk {
+		t.Run(tt, func(t *testing.T) {
+			if _, err := Parse(tt); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	testsNot := []string{
+		"ggo1.1", "1.10", "go1.10.1v2", "go1.10.11x",
+		"", "go1", "go1.", "go1.01", "go1.21.01", "go1.21rc", "go1.21.0rc1", "go2.0",
+		"go1.99999999999999999999", "gotip1",
+		"go1.22.5-", "go1.22.5-1acme", "go1.22.5-acme.linux", "gotip-acme", "go1.22.5--acme",
+	}
+	for _, tt := range testsNot {
+		t.Run(tt, func(t *testing.T) {
+			if _, err := Parse(tt); err == nil {
+				t.Fatalf("expect error")
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		version string
+		want    *Version
+		wantErr bool
+	}{
+		{
+			version: "go1.1",
+			want:    &Version{Raw: "go1.1", Minor: 1, Patch: 0},
+		},
+		{
+			version: "go1.10beta1",
+			want:    &Version{Raw: "go1.10beta1", Minor: 10, Patch: -1, Pre: "beta", PreNum: 1},
+		},
+		{
+			version: "go1.10rc1",
+			want:    &Version{Raw: "go1.10rc1", Minor: 10, Patch: -1, Pre: "rc", PreNum: 1},
+		},
+		{
+			version: "go1.10",
+			want:    &Version{Raw: "go1.10", Minor: 10, Patch: 0},
+		},
+		{
+			version: "go1.10.1",
+			want:    &Version{Raw: "go1.10.1", Minor: 10, Patch: 1},
+		},
+		{
+			version: "go1.21",
+			want:    &Version{Raw: "go1.21", Minor: 21, Patch: -1},
+		},
+		{
+			version: "go1.21.0",
+			want:    &Version{Raw: "go1.21.0", Minor: 21, Patch: 0},
+		},
+		{
+			version: "go1.22",
+			want:    &Version{Raw: "go1.22", Minor: 22, Patch: -1},
+		},
+		{
+			version: "go1.22.5-acme",
+			want:    &Version{Raw: "go1.22.5-acme", Minor: 22, Patch: 5, Variant: "acme"},
+		},
+		{
+			version: "gotip",
+			want:    &Version{Raw: "gotip", Patch: -1},
+		},
+		{
+			version: "go1.22.x",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := Parse(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse()\n  got = %#v,\n want = %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_names(t *testing.T) {
+	tests := []struct {
+		version   string
+		name      string // 发布版本名称，GOROOT 目录名
+		formatted string // $GOBIN 下的命令名
+		lang      string
+		goMod     string
+		toolchain string
+	}{
+		// go1.21 之前
+		{version: "go1.20rc1", name: "go1.20rc1", formatted: "go1.20rc1", lang: "go1.20", goMod: "go 1.20"},
+		{version: "go1.20", name: "go1.20", formatted: "go1.20.0", lang: "go1.20", goMod: "go 1.20"},
+		{version: "go1.20.0", name: "go1.20", formatted: "go1.20.0", lang: "go1.20", goMod: "go 1.20"},
+		{version: "go1.20.14", name: "go1.20.14", formatted: "go1.20.14", lang: "go1.20", goMod: "go 1.20"},
+		{version: "go1.9beta2", name: "go1.9beta2", formatted: "go1.9beta2", lang: "go1.9", goMod: "go 1.9"},
+
+		// go1.21 开始
+		{version: "go1.21rc1", name: "go1.21rc1", formatted: "go1.21rc1", lang: "go1.21", goMod: "go 1.21rc1", toolchain: "go1.21rc1"},
+		{version: "go1.21", name: "go1.21.0", formatted: "go1.21.0", lang: "go1.21", goMod: "go 1.21", toolchain: "go1.21.0"},
+		{version: "go1.21.0", name: "go1.21.0", formatted: "go1.21.0", lang: "go1.21", goMod: "go 1.21.0", toolchain: "go1.21.0"},
+		{version: "go1.22.0", name: "go1.22.0", formatted: "go1.22.0", lang: "go1.22", goMod: "go 1.22.0", toolchain: "go1.22.0"},
+		{version: "go1.22.10", name: "go1.22.10", formatted: "go1.22.10", lang: "go1.22", goMod: "go 1.22.10", toolchain: "go1.22.10"},
+
+		// 变体版本
+		{version: "go1.20-acme", name: "go1.20-acme", formatted: "go1.20.0-acme", lang: "go1.20", goMod: "go 1.20"},
+		{version: "go1.22.5-acme", name: "go1.22.5-acme", formatted: "go1.22.5-acme", lang: "go1.22", goMod: "go 1.22.5", toolchain: "go1.22.5-acme"},
+
+		{version: "gotip", name: "gotip", formatted: "gotip", lang: "gotip", goMod: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := Parse(tt.version)
+			fst.NoError(t, err)
+			fst.Equal(t, tt.name, v.Name())
+			fst.Equal(t, tt.formatted, v.Formatted())
+			fst.Equal(t, tt.lang, v.Lang())
+			fst.Equal(t, tt.goMod, v.GoModLine())
+			fst.Equal(t, tt.name+".linux-amd64.tar.gz", v.ArchiveName("linux", "amd64"))
+			fst.Equal(t, tt.name+".windows-amd64.zip", v.ArchiveName("windows", "amd64"))
+			fst.Equal(t, tt.name+".src.tar.gz", v.SourceArchiveName())
+
+			tc, err := v.Toolchain()
+			fst.Equal(t, tt.toolchain, tc)
+			fst.Equal(t, tt.toolchain == "", errors.Is(err, ErrNoToolchain))
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	// 由旧到新
+	ordered := [][]string{
+		{"go1.9beta1"}, {"go1.9rc2"}, {"go1.9", "go1.9.0"}, {"go1.9.7"},
+		{"go1.10beta1"}, {"go1.10rc1"}, {"go1.10rc2"}, {"go1.10", "go1.10.0"}, {"go1.10.1"}, {"go1.10.2"}, {"go1.10.11"},
+		{"go1.11"},
+		{"go1.20rc1"}, {"go1.20", "go1.20.0"}, {"go1.20.14"},
+		{"go1.21"}, {"go1.21rc2"}, {"go1.21.0"}, {"go1.21.1"}, {"go1.21.13"},
+		{"go1.22"}, {"go1.22rc1"}, {"go1.22.0"}, {"go1.22.0-acme"}, {"go1.22.0-zeta"}, {"go1.22.12"},
+		{"go1.99.999"},
+		{"gotip"},
+	}
+	var all [][]*Version
+	for _, group := range ordered {
+		var vs []*Version
+		for _, name := range group {
+			vs = append(vs, MustParse(name))
+		}
+		all = append(all, vs)
+	}
+	for i, ga := range all {
+		for j, gb := range all {
+			for _, a := range ga {
+				for _, b := range gb {
+					want := 0
+					if i < j {
+						want = -1
+					} else if i > j {
+						want = 1
+					}
+					if got := Compare(a, b); got != want {
+						t.Errorf("Compare(%s, %s) = %d, want %d", a, b, got, want)
+					}
+					if got := Less(a, b); got != (want < 0) {
+						t.Errorf("Less(%s, %s) = %v", a, b, got)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestVersion_Upstream(t *testing.T) {
+	v := MustParse("go1.22.5-acme")
+	fst.True(t, v.IsVariant())
+	u := v.Upstream()
+	fst.Equal(t, &Version{Raw: "go1.22.5", Minor: 22, Patch: 5}, u)
+	fst.False(t, u.IsVariant())
+	fst.Equal(t, "acme", v.Variant)
+
+	v = MustParse("go1.22.5")
+	fst.True(t, v.Upstream() == v)
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{"go1.1", "go1.10.11", "go1.9rc2", "go1.18beta2", "go1.21", "go1.21.0", "gotip", "1.22"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := Parse(s)
+		if err != nil {
+			return
+		}
+		if v.Raw != s {
+			t.Fatalf("Parse(%q).Raw = %q", s, v.Raw)
+		}
+		if v.Compare(v) != 0 {
+			t.Fatalf("%q not equal to itself", s)
+		}
+
+		// 发布版本名称和 3 位版本号都可以再次解析，并且是同一个发布版本
+		for _, name := range []string{v.Name(), v.Formatted()} {
+			nv, err := Parse(name)
+			if err != nil {
+				t.Fatalf("Parse(%q) of %q: %v", name, s, err)
+			}
+			if nv.Name() != v.Name() {
+				t.Fatalf("Parse(%q).Name() = %q, want %q", name, nv.Name(), v.Name())
+			}
+			if v.IsLanguage() {
+				if v.Compare(nv) >= 0 {
+					t.Fatalf("language version %q should be less than %q", s, name)
+				}
+			} else if v.Compare(nv) != 0 {
+				t.Fatalf("%q should be equal to %q", s, name)
+			}
+		}
+
+		if lang := MustParse(v.Lang()); lang.Minor != v.Minor {
+			t.Fatalf("Lang() of %q = %q", s, v.Lang())
+		}
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+// Adopt 接管不是由 smart-go-dl 安装的 SDK，即 adopt 子命令
+//
+// scan 时查找常见安装位置（以及 dirs）中的 SDK 并全部接管，dryRun 时只列出不接管
+func Adopt(ctx context.Context, m *sdkmgr.Manager, dirs []string, scan bool, move bool, dryRun bool) error {
+	opts := sdkmgr.AdoptOptions{Move: move}
+	if !scan {
+		if len(dirs) != 1 {
+			return errors.New("usage: adopt {dir} [--move] | adopt --scan [--dry-run]")
+		}
+		sdk, err := m.Adopt(ctx, dirs[0], opts)
+		if err != nil {
+			return err
+		}
+		logPrint("adopt", sdk.Version.Raw, "in", sdk.GOROOT)
+		return nil
+	}
+
+	list, err := m.Scan(ctx, dirs...)
+	if err != nil {
+		return err
+	}
+	format := "%-16s %-8s %s\n"
+	fmt.Printf(format, "version", "managed", "goroot")
+	for _, f := range list {
+		fmt.Printf(format, f.Version.Raw, fmt.Sprint(f.Managed), f.GOROOT)
+	}
+	if dryRun {
+		return nil
+	}
+	var errs []error
+	for _, f := range list {
+		if f.Managed {
+			continue
+		}
+		// 同一版本已安装在其他目录，如 SDKDir 中已有 go1.22.5 时的 /usr/local/go；
+		// 已在 SDKDir 中、有标记文件的（如 golang.org/dl 安装的）原地接管，
+		// 两者都没有的（如手动放入的自己构建的 Go）不会自动接管，需要明确指定
+		if sdk, err := m.Resolve(ctx, f.Version.Raw); err == nil {
+			target, _ := filepath.EvalSymlinks(sdk.GOROOT)
+			switch {
+			case target != f.GOROOT:
+				logPrint("adopt", f.Version.Raw, "is already installed, skip", f.GOROOT)
+				continue
+			case !m.Unpacked(f.Version):
+				logPrint("adopt", f.GOROOT, "is not installed by smart-go-dl, skip, run 'smart-go-dl adopt", f.GOROOT+"' to adopt it")
+				continue
+			}
+		}
+		sdk, err := m.Adopt(ctx, f.GOROOT, opts)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("adopt %s: %w", f.GOROOT, err))
+			continue
+		}
+		logPrint("adopt", sdk.Version.Raw, "in", sdk.GOROOT)
+	}
+	return errors.Join(errs...)
+}
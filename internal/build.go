@@ -0,0 +1,43 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+// Build 给 version 打上补丁后构建为变体版本，即 build 子命令
+//
+// patches 为补丁文件，也可以是 glob 模式，如 "./patches/*.diff"，会按照文件名排序后依次应用
+func Build(ctx context.Context, m *sdkmgr.Manager, version string, name string, patches []string) error {
+	if len(version) == 0 || len(name) == 0 {
+		return errors.New("usage: build {go1.x.y} --patch {file} --name {go1.x.y-name}")
+	}
+	var files []string
+	for _, p := range patches {
+		ms, err := filepath.Glob(p)
+		if err != nil {
+			return err
+		}
+		if len(ms) == 0 {
+			return fmt.Errorf("patch %q not found", p)
+		}
+		files = append(files, ms...)
+	}
+	sdk, err := m.Build(ctx, version, sdkmgr.BuildOptions{
+		Name:    name,
+		Patches: files,
+	})
+	if err != nil {
+		return err
+	}
+	logPrint("build", "installed", sdk.Version.Raw, "in", sdk.GOROOT)
+	return nil
+}
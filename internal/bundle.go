@@ -0,0 +1,110 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+// BundleCreate 创建离线包
+// versions 和 platforms 都是逗号分隔的列表，如 "go1.22,go1.23"、"linux/amd64,linux/arm64"
+func BundleCreate(ctx context.Context, m *sdkmgr.Manager, output string, versions string, platforms string) error {
+	if len(output) == 0 {
+		return errors.New("output file is required, eg: -o bundle.tar")
+	}
+	opts := sdkmgr.BundleOptions{
+		Versions: splitList(versions),
+	}
+	for _, str := range splitList(platforms) {
+		p, err := sdkmgr.ParsePlatform(str)
+		if err != nil {
+			return err
+		}
+		opts.Platforms = append(opts.Platforms, p)
+	}
+
+	// 先写入临时文件，中断或者失败时不会留下不完整的离线包
+	tmp := output + ".part"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	manifest, err := m.CreateBundle(ctx, f, opts)
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, output); err != nil {
+		return err
+	}
+	logPrint("bundle", "created", output, "versions=", strings.Join(manifest.Versions, ","),
+		"platforms=", strings.Join(manifest.Platforms, ","), "files=", len(manifest.Files))
+	return nil
+}
+
+// BundleImport 导入离线包，并安装其中当前平台的版本，不会访问网络
+func BundleImport(ctx context.Context, m *sdkmgr.Manager, file string) error {
+	if len(file) == 0 {
+		return errors.New("bundle file is required, eg: bundle import bundle.tar")
+	}
+	manifest, err := m.ImportBundle(ctx, file)
+	if err != nil {
+		return err
+	}
+	logPrint("bundle", "imported", file, "to", m.BundleDir())
+
+	versions, err := m.Versions(ctx)
+	if err != nil {
+		return err
+	}
+	current := sdkmgr.CurrentPlatform()
+	for _, version := range manifest.Versions {
+		v, err := sdkmgr.ParseVersion(version)
+		if err != nil {
+			return err
+		}
+		if !hasBundleFile(manifest, v.ArchiveName(current.GOOS, current.GOARCH)) {
+			logPrint("bundle", "skip", version, "no archive for", current.String())
+			continue
+		}
+		// 是次要版本的最新版本时，按照次要版本安装，以同时创建 $GOBIN/go1.x
+		// 否则使用完整的版本号，如 go1.22.0（Name 为 go1.22），避免安装成 go1.22 的最新版本
+		name := v.Formatted()
+		if mv := versions.Get(v.Normalized); mv != nil && mv.Latest().Raw == v.Raw {
+			name = v.Normalized
+		}
+		if _, err = m.Install(ctx, name, sdkmgr.ChannelAny); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasBundleFile(manifest *sdkmgr.BundleManifest, name string) bool {
+	for _, f := range manifest.Files {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(str string) []string {
+	var result []string
+	for _, s := range strings.Split(str, ",") {
+		if s = strings.TrimSpace(s); len(s) > 0 {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fsgo/fst"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+// testArchive 只包含 go/bin/go 的官方格式的打包文件
+func testArchive(t *testing.T, name string) []byte {
+	t.Helper()
+	bf := &bytes.Buffer{}
+	if strings.HasSuffix(name, ".zip") {
+		zw := zip.NewWriter(bf)
+		w, err := zw.Create("go/bin/go.exe")
+		fst.NoError(t, err)
+		_, err = w.Write([]byte(name))
+		fst.NoError(t, err)
+		fst.NoError(t, zw.Close())
+		return bf.Bytes()
+	}
+	gw := gzip.NewWriter(bf)
+	tw := tar.NewWriter(gw)
+	fst.NoError(t, tw.WriteHeader(&tar.Header{Name: "go/bin/go", Mode: 0755, Size: int64(len(name))}))
+	_, err := tw.Write([]byte(name))
+	fst.NoError(t, err)
+	fst.NoError(t, tw.Close())
+	fst.NoError(t, gw.Close())
+	return bf.Bytes()
+}
+
+func TestBundleImport(t *testing.T) {
+	ctx := context.Background()
+	src := t.TempDir()
+	p := sdkmgr.CurrentPlatform()
+	for _, version := range []string{"go1.20", "go1.20.14"} {
+		v, err := sdkmgr.ParseVersion(version)
+		fst.NoError(t, err)
+		name := v.ArchiveName(p.GOOS, p.GOARCH)
+		fst.NoError(t, os.WriteFile(filepath.Join(src, name), testArchive(t, name), 0644))
+	}
+	online, err := sdkmgr.New(sdkmgr.Options{
+		SDKDir:   t.TempDir(),
+		Indexes:  []sdkmgr.Index{&sdkmgr.Dir{Path: src}},
+		Fetchers: []sdkmgr.Fetcher{&sdkmgr.Dir{Path: src}},
+	})
+	fst.NoError(t, err)
+	fp := filepath.Join(t.TempDir(), "bundle.tar")
+	f, err := os.Create(fp)
+	fst.NoError(t, err)
+	// 只打包 go1.20，不是 go1.20 的最新版本
+	_, err = online.CreateBundle(ctx, f, sdkmgr.BundleOptions{Versions: []string{"go1.20.0"}})
+	fst.NoError(t, err)
+	fst.NoError(t, f.Close())
+
+	dir := t.TempDir()
+	offline, err := sdkmgr.New(sdkmgr.Options{
+		SDKDir:  filepath.Join(dir, "sdk"),
+		DataDir: filepath.Join(dir, "data"),
+		GOBIN:   filepath.Join(dir, "bin"),
+		Offline: true,
+	})
+	fst.NoError(t, err)
+	fst.NoError(t, BundleImport(ctx, offline, fp))
+
+	v, err := sdkmgr.ParseVersion("go1.20")
+	fst.NoError(t, err)
+	fst.True(t, offline.Installed(v))
+	v, err = sdkmgr.ParseVersion("go1.20.14")
+	fst.NoError(t, err)
+	fst.False(t, offline.Installed(v))
+}
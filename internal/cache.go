@@ -0,0 +1,64 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+// Cache 管理下载的打包文件的缓存，action 为 list、prune、clear
+func Cache(ctx context.Context, m *sdkmgr.Manager, action string) error {
+	switch action {
+	case "list", "":
+		return cacheList(ctx, m)
+	case "prune":
+		removed, err := m.CachePrune(ctx)
+		if err != nil {
+			return err
+		}
+		logPrint("cache", "pruned", len(removed), "archives")
+		return nil
+	case "clear":
+		return m.CacheClear(ctx)
+	default:
+		return fmt.Errorf("unknown cache action %q, expect list, prune or clear", action)
+	}
+}
+
+func cacheList(ctx context.Context, m *sdkmgr.Manager) error {
+	list, err := m.CacheList(ctx)
+	if err != nil {
+		return err
+	}
+	format := "%-36s %-12s %-10s %s\n"
+	fmt.Printf(format, "name", "sha256", "size", "last used")
+	var total int64
+	seen := make(map[string]bool)
+	for _, e := range list {
+		fmt.Printf(format, e.Name, e.SHA256[:12], formatSize(e.Size), e.LastUsed.Format("2006-01-02 15:04:05"))
+		if !seen[e.Path] {
+			seen[e.Path] = true
+			total += e.Size
+		}
+	}
+	logPrint("cache", m.CacheDir(), "total", formatSize(total))
+	return nil
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1fGB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%dB", size)
+	}
+}
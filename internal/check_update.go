@@ -0,0 +1,180 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+const updateStatusFile = "update-check.json"
+
+// updateStatus 后台检查更新的结果
+type updateStatus struct {
+	// CheckTime 上次启动检查的时间
+	CheckTime time.Time
+
+	// Updates 已安装的次要版本可以更新到的版本，如 go1.22 -> go1.22.6
+	Updates map[string]string
+
+	// NoticeTime 每个次要版本上次提示更新的时间
+	NoticeTime map[string]time.Time
+}
+
+func updateStatusPath(m *sdkmgr.Manager) string {
+	return filepath.Join(m.DataDir(), updateStatusFile)
+}
+
+func loadUpdateStatus(m *sdkmgr.Manager) *updateStatus {
+	st := &updateStatus{}
+	if bf, err := os.ReadFile(updateStatusPath(m)); err == nil {
+		_ = json.Unmarshal(bf, st)
+	}
+	if st.Updates == nil {
+		st.Updates = make(map[string]string)
+	}
+	if st.NoticeTime == nil {
+		st.NoticeTime = make(map[string]time.Time)
+	}
+	return st
+}
+
+func (st *updateStatus) save(m *sdkmgr.Manager) error {
+	bf, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	fp := updateStatusPath(m)
+	tmp := fp + ".tmp"
+	if err = os.WriteFile(tmp, bf, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, fp)
+}
+
+// CheckUpdate 检查已安装的次要版本是否有新的修订版本，并记录检查结果，
+// 以 go 别名运行时会依据此结果给出提示
+func CheckUpdate(ctx context.Context, m *sdkmgr.Manager) error {
+	versions, err := m.Versions(ctx)
+	if err != nil {
+		return err
+	}
+	st := loadUpdateStatus(m)
+	st.CheckTime = time.Now()
+	st.Updates = make(map[string]string)
+	for _, mv := range versions {
+		if mv.NormalizedVersion == "gotip" || !m.MinorInstalled(mv) {
+			continue
+		}
+		last := mv.Latest()
+		if m.Installed(last) {
+			continue
+		}
+		st.Updates[mv.NormalizedVersion] = last.Raw
+		logPrint("update", last.RawFormatted(), "available, run 'smart-go-dl update", mv.NormalizedVersion+"'")
+	}
+	return st.save(m)
+}
+
+// tryCheckUpdate 以 go 别名运行时、启动 go 命令之前调用，只读取上次检查的结果，不会等待检查完成
+//
+// 若距离上次检查已超过配置的时间间隔，会启动一个独立的后台进程执行 check-update，
+// 同时依据上次检查的结果，在每个时间间隔内最多提示一次当前次要版本有新的修订版本。
+// 多个 go 命令同时运行时，由锁文件保证只有一个会启动检查和提示
+//
+// version: 当前运行的版本，如 go1.22、go1.22.5
+func tryCheckUpdate(m *sdkmgr.Manager, version string) {
+	interval := defaultConfig.getCheckUpdateInterval()
+	if interval <= 0 || defaultConfig.Offline {
+		return
+	}
+	now := time.Now()
+	if !needUpdateAction(m, loadUpdateStatus(m), version, now, interval) {
+		return
+	}
+	unlock, err := sdkmgr.TryLockFile(filepath.Join(m.DataDir(), updateLockFile))
+	if err != nil {
+		log.Println("check-update, lock failed:", err)
+		return
+	}
+	if unlock == nil {
+		// 其他进程正在处理
+		return
+	}
+	defer unlock()
+
+	// 持有锁之后重新读取，其他进程可能刚处理完
+	st := loadUpdateStatus(m)
+	var changed bool
+	if last := newerVersion(m, st, version); last != nil && now.Sub(st.NoticeTime[last.Normalized]) >= interval {
+		fmt.Fprintf(os.Stderr, "%s available, run smart-go-dl update %s\n", last.RawFormatted(), last.Normalized)
+		st.NoticeTime[last.Normalized] = now
+		changed = true
+	}
+	// 只有成功启动检查后才记录检查时间，启动失败时下次运行会再尝试
+	if now.Sub(st.CheckTime) >= interval && startCheckUpdate() {
+		st.CheckTime = now
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	if err = st.save(m); err != nil {
+		log.Println("save update status failed:", err)
+	}
+}
+
+// updateLockFile 以 go 别名运行时启动检查和提示更新的锁文件，在 DataDir 下
+const updateLockFile = "update-check.lock"
+
+// needUpdateAction 是否需要启动检查或者提示更新，不需要时不用获取锁
+func needUpdateAction(m *sdkmgr.Manager, st *updateStatus, version string, now time.Time, interval time.Duration) bool {
+	if now.Sub(st.CheckTime) >= interval {
+		return true
+	}
+	last := newerVersion(m, st, version)
+	return last != nil && now.Sub(st.NoticeTime[last.Normalized]) >= interval
+}
+
+// newerVersion 上次检查的结果中，version 所在的次要版本可以更新到的、还没有安装的版本
+func newerVersion(m *sdkmgr.Manager, st *updateStatus, version string) *sdkmgr.Version {
+	v, err := sdkmgr.ParseVersion(version)
+	if err != nil {
+		return nil
+	}
+	last, err := sdkmgr.ParseVersion(st.Updates[v.Normalized])
+	if err != nil || m.Installed(last) {
+		return nil
+	}
+	return last
+}
+
+// startCheckUpdate 启动独立的后台进程执行 check-update，不等待其结束，返回是否启动成功
+func startCheckUpdate() bool {
+	self, err := os.Executable()
+	if err != nil {
+		log.Println("check-update, find executable failed:", err)
+		return false
+	}
+	cmd := exec.Command(self, "check-update")
+	// 以 go 别名运行时，需要让子进程以 smart-go-dl 的身份运行
+	cmd.Args[0] = "smart-go-dl"
+	setDetached(cmd)
+	if err = cmd.Start(); err != nil {
+		log.Println("check-update, start failed:", err)
+		return false
+	}
+	log.Println("check-update started, pid=", cmd.Process.Pid)
+	_ = cmd.Process.Release()
+	return true
+}
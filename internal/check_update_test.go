@@ -0,0 +1,56 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+func TestTryCheckUpdate(t *testing.T) {
+	old := defaultConfig
+	defer func() {
+		defaultConfig = old
+	}()
+	defaultConfig = &Config{CheckUpdateInterval: "24h"}
+
+	m := testManager(t, "go1.22.4")
+	fst.NoError(t, os.MkdirAll(m.DataDir(), 0755))
+	now := time.Now()
+	interval := 24 * time.Hour
+
+	// 刚检查过，不会启动 check-update
+	st := loadUpdateStatus(m)
+	st.CheckTime = now
+	fst.NoError(t, st.save(m))
+	fst.False(t, needUpdateAction(m, st, "go1.22", now, interval))
+
+	st.Updates["go1.22"] = "go1.22.6"
+	fst.NoError(t, st.save(m))
+	fst.True(t, needUpdateAction(m, st, "go1.22", now, interval))
+	fst.Equal(t, "go1.22.6", newerVersion(m, st, "go1.22.4").Raw)
+	fst.Nil(t, newerVersion(m, st, "go1.21"))
+
+	// 其他进程持有锁时不提示
+	lock := filepath.Join(m.DataDir(), updateLockFile)
+	unlock, err := sdkmgr.TryLockFile(lock)
+	fst.NoError(t, err)
+	tryCheckUpdate(m, "go1.22")
+	fst.True(t, loadUpdateStatus(m).NoticeTime["go1.22"].IsZero())
+	unlock()
+
+	tryCheckUpdate(m, "go1.22")
+	st = loadUpdateStatus(m)
+	fst.False(t, st.NoticeTime["go1.22"].IsZero())
+	fst.False(t, needUpdateAction(m, st, "go1.22", time.Now(), interval))
+	_, err = os.Stat(lock)
+	fst.True(t, os.IsNotExist(err))
+}
@@ -6,44 +6,16 @@ package internal
 
 import (
 	"fmt"
-	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
-	"time"
-
-	"github.com/fsgo/cmdutil"
 )
 
-var gTmpDir string
-
-// GetDataDir 获取当前应用的缓存目录, 默认路径为 ~/sdk/smart-go-dl
-func GetDataDir() string {
-	if len(gTmpDir) != 0 {
-		return gTmpDir
-	}
-	sdk := defaultConfig.getSDKDir()
-	return filepath.Join(sdk, "smart-go-dl")
-}
-
-func chdir(dir string) error {
-	err := os.Chdir(dir)
-	if err == nil {
-		logPrint("chdir", dir)
-	} else {
-		logPrint("chdir", dir, "failed:", err)
-	}
-	return err
-}
-
-// DataDir 获取临时目录，路径为 ~/sdk/smart-go-dl
+// DataDir 获取当前应用的缓存目录, 默认路径为 ~/sdk/smart-go-dl
 func DataDir() string {
-	if len(gTmpDir) != 0 {
-		return gTmpDir
-	}
-	return GetDataDir()
+	return filepath.Join(defaultConfig.getSDKDir(), "smart-go-dl")
 }
 
 var goBinPath string
@@ -92,11 +64,6 @@ func isWindows() bool {
 	return runtime.GOOS == "windows"
 }
 
-func goroot(version string) (string, error) {
-	dir := defaultConfig.getSDKDir()
-	return filepath.Join(dir, version), nil
-}
-
 func green(txt string) string {
 	return colorText(txt, 32)
 }
@@ -109,43 +76,6 @@ func colorText(txt string, color int) string {
 	return fmt.Sprintf("\x1b[0;%dm%s\x1b[0m", color, txt)
 }
 
-func getOS() string {
-	return runtime.GOOS
-}
-
-func copyFile(src, dst string) error {
-	logPrint("trace", "copyFile", src, "->", dst)
-	sf, err := os.Open(src)
-	if err != nil {
-		wd, _ := os.Getwd()
-		return fmt.Errorf("wd=%q, os.Open(%q) %w", wd, src, err)
-	}
-	defer sf.Close()
-	si, err := sf.Stat()
-	if err != nil {
-		return err
-	}
-	_ = os.Remove(dst)
-	df, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_RDWR, si.Mode())
-	if err != nil {
-		return err
-	}
-	defer df.Close()
-	_, err = io.Copy(df, sf)
-	return err
-}
-
-func newWget() *cmdutil.Wget {
-	gt := &cmdutil.Wget{
-		Timeout:            10 * time.Minute,
-		LogWriter:          os.Stderr,
-		Proxy:              defaultConfig.getProxy(),
-		ConnectTimeout:     5 * time.Second,
-		InsecureSkipVerify: defaultConfig.InsecureSkipVerify,
-	}
-	return gt
-}
-
 func logPrint(key string, msgs ...any) {
 	ks := fmt.Sprintf("%-10s : ", key)
 	var bs strings.Builder
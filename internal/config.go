@@ -10,9 +10,13 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
 )
 
 // Config 当前程序的配置
@@ -35,6 +39,44 @@ type Config struct {
 	// SDKDir 安装目录，可选，默认为 ~/sdk/
 	// 不同的 Go 版本在 SDKDir 中以子目录方式存在，如 ~/sdk/go1.22.0/
 	SDKDir string
+
+	// SharedSDKDirs 只读的共享安装目录，可选，如运维维护的 ["/opt/go-sdks"]
+	// 运行 go1.x、list 时会依次查找 SDKDir 和 SharedSDKDirs 中已安装的版本，
+	// install、remove、clean 等只会修改 SDKDir
+	SharedSDKDirs []string
+
+	// CheckUpdateInterval 后台检查新修订版本的时间间隔，可选，如 "24h"
+	// 为空时不检查，配置后以 go 别名运行时，每个时间间隔内最多检查和提示一次
+	CheckUpdateInterval string
+
+	// Track 版本跟踪策略，可选
+	Track TrackConfig
+
+	// Offline 离线模式，可选，也可以使用 --offline 参数
+	// 不会访问网络：不更新版本列表，只从本地的打包文件来源（如 "dir:{path}"）安装
+	Offline bool
+
+	// IndexRefreshInterval 版本列表的更新间隔，可选，默认为 "1m"
+	// 只有 install、update、list 等需要版本列表的命令才会检查，超过此间隔才会更新
+	IndexRefreshInterval string
+
+	// Indexes 版本列表的来源，可选，会依次尝试，默认为 ["git"]
+	// 可选值见 sdkmgr.ParseIndex，如 "godev"、"goproxy"、"feed:{url}"、"dir:{path}"
+	Indexes []string
+
+	// Fetchers 下载 go 打包文件的来源，可选，会依次尝试，默认为 ["mirror"]
+	// 可选值见 sdkmgr.ParseFetcher，如 "mirror"、"goproxy"、"dir:{path}"
+	Fetchers []string
+
+	// GoRepo Go 源码仓库地址，用于构建 gotip，可选，默认为 https://go.googlesource.com/go
+	GoRepo string
+
+	// CacheDir 下载的打包文件的缓存目录，可选，默认为 {DataDir}/cache
+	// 可以配置为多个用户、多个容器共享的目录
+	CacheDir string
+
+	// CacheSize 缓存的大小上限，可选，如 "10GB"、"500MB"，默认为 "4GB"，"0" 表示不缓存
+	CacheSize string
 }
 
 func (c *Config) getProxy() func(*http.Request) (*url.URL, error) {
@@ -58,23 +100,104 @@ func (c *Config) getSDKDir() string {
 	return filepath.Join(home, "sdk")
 }
 
-func (c *Config) getTarURLs(fp string) []string {
-	ps := c.getTarURLPrefix()
-	var result []string
-	for _, p := range ps {
-		p = strings.TrimSpace(p)
-		if len(p) == 0 {
-			continue
+func (c *Config) getCheckUpdateInterval() time.Duration {
+	if len(c.CheckUpdateInterval) == 0 {
+		return 0
+	}
+	dur, err := time.ParseDuration(c.CheckUpdateInterval)
+	if err != nil {
+		logPrint("config", "invalid CheckUpdateInterval", c.CheckUpdateInterval, err)
+		return 0
+	}
+	return dur
+}
+
+func (c *Config) getIndexRefreshInterval() time.Duration {
+	if len(c.IndexRefreshInterval) == 0 {
+		return time.Minute
+	}
+	dur, err := time.ParseDuration(c.IndexRefreshInterval)
+	if err != nil {
+		logPrint("config", "invalid IndexRefreshInterval", c.IndexRefreshInterval, err)
+		return time.Minute
+	}
+	return dur
+}
+
+// getCacheSize 缓存的大小上限，单位字节，见 sdkmgr.Options.CacheSize
+func (c *Config) getCacheSize() int64 {
+	str := strings.TrimSpace(c.CacheSize)
+	if len(str) == 0 {
+		return 0
+	}
+	size, err := parseSize(str)
+	if err != nil {
+		logPrint("config", "invalid CacheSize", c.CacheSize, err)
+		return 0
+	}
+	if size == 0 {
+		return -1
+	}
+	return size
+}
+
+// parseSize 解析文件大小，如 "10GB"、"500MB"、"1024"
+func parseSize(str string) (int64, error) {
+	units := []struct {
+		suffix string
+		size   int64
+	}{
+		{"TB", 1 << 40},
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+	str = strings.ToUpper(strings.TrimSpace(str))
+	unit := int64(1)
+	for _, u := range units {
+		if num, ok := strings.CutSuffix(str, u.suffix); ok {
+			str, unit = strings.TrimSpace(num), u.size
+			break
 		}
-		var b strings.Builder
-		b.WriteString(p)
-		if !strings.HasSuffix(p, "/") {
-			b.WriteString("/")
+	}
+	num, err := strconv.ParseFloat(str, 64)
+	if err != nil || num < 0 {
+		return 0, fmt.Errorf("invalid size %q", str)
+	}
+	return int64(num * float64(unit)), nil
+}
+
+func (c *Config) getIndexes() ([]sdkmgr.Index, error) {
+	var result []sdkmgr.Index
+	for _, spec := range c.Indexes {
+		idx, err := sdkmgr.ParseIndex(c.withGoProxy(spec))
+		if err != nil {
+			return nil, err
 		}
-		b.WriteString(fp)
-		result = append(result, b.String())
+		result = append(result, idx)
 	}
-	return result
+	return result, nil
+}
+
+func (c *Config) getFetchers() ([]sdkmgr.Fetcher, error) {
+	var result []sdkmgr.Fetcher
+	for _, spec := range c.Fetchers {
+		f, err := sdkmgr.ParseFetcher(c.withGoProxy(spec))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+// withGoProxy 若配置了 GoProxy，"goproxy" 使用配置的地址
+func (c *Config) withGoProxy(spec string) string {
+	if spec == "goproxy" && len(c.GoProxy) > 0 {
+		return "goproxy:" + c.GoProxy
+	}
+	return spec
 }
 
 func (c *Config) trySetProxyEnv() {
@@ -87,26 +210,18 @@ func (c *Config) trySetProxyEnv() {
 	os.Setenv("https_proxy", c.Proxy)
 }
 
-func SDKRootDir() string {
-	return defaultConfig.getSDKDir()
-}
-
-var tarURLPrefixDefault = []string{
-	"https://go.dev/dl/",
-	"https://dl-ssl.google.com/go/", // 部分不能使用 tls 的尝试这个
-	"https://dl.google.com/go/",
-	// "https://studygolang.com/dl/golang/",
-}
-
 func (c *Config) getTarURLPrefix() []string {
 	if len(c.TarURLPrefix) > 0 {
 		return strings.Split(c.TarURLPrefix, ",")
 	}
-	return tarURLPrefixDefault
+	return sdkmgr.DefaultMirrors
 }
 
 var defaultConfig = &Config{}
 
+// configErr 解析配置文件失败的原因，失败时使用默认配置，见 doctor
+var configErr error
+
 func configPath() string {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -115,29 +230,39 @@ func configPath() string {
 	return filepath.Join(home, ".config", "smart-go-dl", "app.toml")
 }
 
+// loadConfig 读取配置文件，不存在时创建配置模板
 func loadConfig() {
+	fp := configPath()
+	if !readConfig() {
+		_ = os.MkdirAll(filepath.Dir(fp), 0755)
+		_ = os.WriteFile(fp, []byte(cfgTpl), 0644)
+	}
+}
+
+// readConfig 只读取配置文件，不会写文件，以 go 别名运行时使用，配置文件不存在时返回 false
+func readConfig() bool {
 	fp := configPath()
 	logPrint("config", fp)
 	content, err := os.ReadFile(fp)
 	if err != nil && os.IsNotExist(err) {
-		_ = os.MkdirAll(filepath.Dir(fp), 0755)
-		_ = os.WriteFile(fp, []byte(cfgTpl), 0644)
-		return
+		return false
 	}
 	var cfg *Config
 	if err = toml.Unmarshal(content, &cfg); err != nil {
+		configErr = err
 		logPrint("config", "ignored,parser", fp, "failed,", err)
-		return
+		return true
 	}
 	cfg.Proxy = strings.TrimSpace(cfg.Proxy)
 	cfg.TarURLPrefix = strings.TrimSpace(cfg.TarURLPrefix)
 	defaultConfig = cfg
 	cfg.trySetProxyEnv()
 	logPrint("sdk dir", cfg.getSDKDir())
+	return true
 }
 
 func printProxy() {
-	req, _ := http.NewRequest(http.MethodGet, tarURLPrefixDefault[0], nil)
+	req, _ := http.NewRequest(http.MethodGet, sdkmgr.DefaultMirrors[0], nil)
 	proxyFn := defaultConfig.getProxy()
 	pu, err := proxyFn(req)
 	if err != nil {
@@ -165,4 +290,46 @@ var cfgTpl = `
 # 安装目录，可选，默认为 ~/sdk
 # 不同的 Go 版本在 SDKDir 中以子目录方式存在，如 ~/sdk/go1.22.0/
 # SDKDir = "D:\\soft\\sdk\\"
+
+# 后台检查已安装版本是否有新的修订版本的时间间隔，可选，默认不检查
+# 配置后以 go、go1.x 等别名运行时，会在后台更新版本列表，并在终端提示可以更新的版本
+# CheckUpdateInterval = "24h"
+
+# 离线模式，可选，默认 false，也可以使用 --offline 参数
+# 不会访问网络：不更新版本列表，只从本地的打包文件来源（如 Fetchers 中的 "dir:{path}"）安装
+# Offline = true
+
+# 版本列表的更新间隔，可选，默认为 "1m"
+# 只有 install、update、list 等需要版本列表的命令才会检查，超过此间隔才会更新
+# 也可以使用 --refresh 强制更新，或者 --no-refresh 不更新
+# IndexRefreshInterval = "24h"
+
+# 版本列表的来源，可选，会依次尝试，使用第一个有数据的，默认为 ["git"]
+# git: golang/dl 的 git 仓库，godev: go.dev 的 JSON 接口，feed:{url}: 相同格式的内部接口，
+# goproxy: GOPROXY 中的 golang.org/toolchain 模块(go1.21 及之后)，dir:{path}: 本地目录中的打包文件
+# Indexes = ["godev", "git"]
+
+# 下载 Go 打包文件的来源，可选，会依次尝试，默认为 ["mirror"]
+# mirror: TarURLPrefix 中的地址，mirror:{url},{url}: 指定的地址，godev: go.dev 官方地址，
+# goproxy: GOPROXY 中的 golang.org/toolchain 模块，dir:{path}: 本地目录中的打包文件
+# Fetchers = ["dir:/data/go-archives", "mirror", "goproxy"]
+
+# Go 源码仓库地址，用于从源码构建 gotip，可选，默认为 "https://go.googlesource.com/go"
+# GoRepo = "https://github.com/golang/go.git"
+
+# 下载的打包文件的缓存目录，可选，默认为 {SDKDir}/smart-go-dl/cache
+# 缓存以 sha256 寻址，可以配置为多个用户、多个容器共享的目录，安装时会优先使用
+# CacheDir = "/data/smart-go-dl-cache"
+
+# 缓存的大小上限，可选，默认为 "4GB"，超出时淘汰最久未使用的，"0" 表示不缓存
+# CacheSize = "10GB"
+
+# 版本跟踪策略，可选，执行 "update" 时生效
+# [Track]
+# 总是保持最新的 2 个正式次要版本，有新的次要版本(如 go1.26)发布时会自动安装
+# Stable = 2
+# 跟踪最新的预览版本（beta、rc），如 go1.27rc1
+# Pre = true
+# 删除不在跟踪范围内的次要版本，被 lock 的版本不会被删除，需要同时配置 Stable
+# Retire = true
 `
@@ -0,0 +1,27 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+//go:build !windows
+
+package internal
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// setDetached 让子进程脱离当前会话，当前进程退出后可继续运行
+func setDetached(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+// setInterrupt context 取消时先给子进程发送 Interrupt 信号，让其可以正常退出
+func setInterrupt(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = 10 * time.Second
+}
@@ -0,0 +1,24 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+const detachedProcess = 0x00000008
+
+// setDetached 让子进程脱离当前控制台，当前进程退出后可继续运行
+func setDetached(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: detachedProcess}
+}
+
+// setInterrupt context 取消时结束子进程，windows 不支持发送 Interrupt 信号，
+// 子进程和当前进程在同一个控制台中，一般已经收到了 Ctrl-C
+func setInterrupt(cmd *exec.Cmd) {
+	cmd.WaitDelay = 10 * time.Second
+}
@@ -0,0 +1,110 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+// Doctor 检查整个安装，并输出检查结果，即 doctor 子命令，fix 时修复可以自动修复的问题，asJSON 时输出 JSON
+// 除了 sdkmgr.Manager.Doctor 的检查项，还会检查配置文件 app.toml 能否解析；有未修复的问题时返回错误
+func Doctor(ctx context.Context, m *sdkmgr.Manager, fix bool, asJSON bool) error {
+	checks, err := m.Doctor(ctx, fix)
+	if err != nil {
+		return err
+	}
+	checks = append(checks, checkConfig(fix))
+
+	var found, fixable int
+	for _, c := range checks {
+		for _, p := range c.Problems {
+			if p.Fixed {
+				continue
+			}
+			found++
+			if len(p.Fix) > 0 {
+				fixable++
+			}
+		}
+	}
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(checks); err != nil {
+			return err
+		}
+	} else {
+		printChecks(checks)
+	}
+	if found == 0 {
+		return nil
+	}
+	if fixable > 0 && !fix {
+		return fmt.Errorf("%d problems found, %d can be fixed by 'smart-go-dl doctor --fix'", found, fixable)
+	}
+	return fmt.Errorf("%d problems found", found)
+}
+
+// checkConfig 配置文件能否解析，解析失败时会使用默认配置，修复时备份后使用默认的配置文件
+func checkConfig(fix bool) *sdkmgr.Check {
+	c := &sdkmgr.Check{Name: "config", Title: "app.toml can be parsed"}
+	if configErr == nil {
+		return c
+	}
+	fp := configPath()
+	bak := fp + "." + time.Now().Format("20060102150405") + ".bak"
+	p := &sdkmgr.Problem{
+		Path:    fp,
+		Message: "ignored, using the default config: " + configErr.Error(),
+		Fix:     "move it to " + bak + " and create the default one",
+	}
+	c.Problems = append(c.Problems, p)
+	if !fix {
+		return c
+	}
+	err := os.Rename(fp, bak)
+	if err == nil {
+		err = os.WriteFile(fp, []byte(cfgTpl), 0644)
+	}
+	if err != nil {
+		p.Error = err.Error()
+	} else {
+		p.Fixed = true
+	}
+	return c
+}
+
+func printChecks(checks []*sdkmgr.Check) {
+	for _, c := range checks {
+		status := "ok"
+		if !c.OK() {
+			status = "fail"
+		} else if len(c.Problems) > 0 {
+			status = "fixed"
+		}
+		fmt.Printf("[%-5s] %-12s %s\n", status, c.Name, c.Title)
+		for _, p := range c.Problems {
+			line := "  - " + p.Message
+			if len(p.Path) > 0 {
+				line = "  - " + p.Path + ": " + p.Message
+			}
+			switch {
+			case p.Fixed:
+				line += " (fixed: " + p.Fix + ")"
+			case len(p.Error) > 0:
+				line += " (fix failed: " + p.Error + ")"
+			case len(p.Fix) > 0:
+				line += " (fix: " + p.Fix + ")"
+			}
+			fmt.Println(line)
+		}
+	}
+}
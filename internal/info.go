@@ -0,0 +1,136 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+// Info 输出一个版本的详细信息，即 info 子命令，asJSON 时输出 JSON
+func Info(ctx context.Context, m *sdkmgr.Manager, version string, asJSON bool) error {
+	if len(version) == 0 {
+		return errors.New("usage: info {go1.x.y} [--json]")
+	}
+	info, err := m.Info(ctx, version)
+	if err != nil {
+		return err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	addShimInfo(ctx, m, info, wd)
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+
+	const timeLayout = "2006-01-02 15:04:05"
+	format := "%-12s %s\n"
+	fmt.Printf(format, "version", info.Version)
+	fmt.Printf(format, "installed", yesNo(info.Installed))
+	if v, err := sdkmgr.ParseVersion(info.Newer); err == nil {
+		fmt.Printf(format, "newer", info.Newer+" available, run 'smart-go-dl update "+v.Normalized+"'")
+	}
+	if !info.Installed {
+		return nil
+	}
+	fmt.Printf(format, "goroot", info.GOROOT)
+	root := info.Root
+	if info.Shared {
+		root += " (read-only)"
+	}
+	fmt.Printf(format, "root", root)
+	fmt.Printf(format, "size", formatSize(info.Size))
+	if mf := info.Manifest; mf != nil {
+		fmt.Printf(format, "installed at", mf.Installed.Format(timeLayout))
+		fmt.Printf(format, "source", mf.Source)
+		if len(mf.SHA256) > 0 {
+			fmt.Printf(format, "sha256", mf.SHA256)
+		}
+		fmt.Printf(format, "installer", mf.Tool)
+	} else {
+		fmt.Printf(format, "manifest", "none, installed by an older smart-go-dl, golang.org/dl or by hand")
+	}
+	fmt.Printf(format, "locked", yesNo(info.Locked))
+	if info.LastUsed != nil {
+		fmt.Printf(format, "last used", info.LastUsed.Format(timeLayout))
+	}
+	fmt.Printf(format, "links", strings.Join(info.Links, " "))
+	fmt.Printf(format, "go.latest", yesNo(info.Latest))
+	if pin := info.Pin; pin != nil {
+		fmt.Printf(format, "pinned", pin.Version+" in "+pin.File)
+	} else {
+		fmt.Printf(format, "pinned", "no")
+	}
+	if vi := info.Variant; vi != nil {
+		var names []string
+		for _, p := range vi.Patches {
+			names = append(names, p.Name)
+		}
+		fmt.Printf(format, "upstream", vi.Upstream)
+		fmt.Printf(format, "patches", strings.Join(names, " "))
+	}
+	if ti := info.Tip; ti != nil {
+		fmt.Printf(format, "ref", ti.Ref)
+		fmt.Printf(format, "commit", ti.Commit)
+	}
+	return nil
+}
+
+// addShimInfo 依据 go、go.latest 运行时的解析（同 TryRunGo），补充 $GOBIN 中会运行该版本的 go、go.latest，
+// 以及目录 dir 所在的项目中指定的版本
+func addShimInfo(ctx context.Context, m *sdkmgr.Manager, info *sdkmgr.VersionInfo, dir string) {
+	if !info.Installed {
+		return
+	}
+	goroot, _ := filepath.EvalSymlinks(info.GOROOT)
+	sameGOROOT := func(gr string) bool {
+		target, _ := filepath.EvalSymlinks(gr)
+		return target == goroot
+	}
+	// toolchain 指令是最低版本，只有同名时才是指定的该版本
+	if tc := sdkmgr.FindToolchain(dir); tc != nil && tc.Version == info.Version {
+		info.Pin = tc
+	} else if pin := sdkmgr.FindPin(dir); pin != nil {
+		if sdk, err := m.Resolve(ctx, pin.Version); err == nil && sameGOROOT(sdk.GOROOT) {
+			info.Pin = pin
+		}
+	}
+	for _, name := range []string{"go", "go.latest"} {
+		r, err := resolveShim(ctx, m, name)
+		if err != nil || !sameGOROOT(r.GOROOT) {
+			continue
+		}
+		if len(m.GOBIN()) == 0 {
+			continue
+		}
+		if _, err = os.Stat(filepath.Join(m.GOBIN(), name+exe())); err != nil {
+			continue
+		}
+		info.Links = append(info.Links, name)
+		if name == "go.latest" {
+			info.Latest = true
+		}
+	}
+	sort.Strings(info.Links)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
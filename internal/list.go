@@ -8,28 +8,30 @@ import (
 	"context"
 	"fmt"
 	"strings"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
 )
 
-// List 列出已安装和可安装的 go 版本
-func List(ctx context.Context) error {
-	versions, err := LastVersions(ctx)
+// List 列出已安装和可安装的 go 版本，已安装的变体版本单独一行，如 go1.22-acme
+func List(ctx context.Context, m *sdkmgr.Manager) error {
+	versions, err := m.AllVersions(ctx)
 	if err != nil {
 		return err
 	}
 
-	format := "%-20s %-20s %-20s\n"
-	formatColor := "%-31s %-20s %-20s\n"
+	format := "%-12s %-14s %-14s %s\n"
+	formatColor := "%-23s %-14s %-14s %s\n"
 	fmt.Println(strings.Repeat("-", 80))
-	fmt.Printf(format, "version", "latest", "installed")
+	fmt.Printf(format, "version", "stable", "pre", "installed")
 	fmt.Println(strings.Repeat("-", 80))
 
 	for _, mv := range versions {
 		latest := mv.PatchVersions[0]
 		cell1 := mv.NormalizedVersion
 		localFormat := format
-		installed := strings.Join(installedVersions(mv.PatchVersions), " ")
+		installed := strings.Join(installedVersions(m, mv.PatchVersions), " ")
 		if !isWindows() {
-			if latest.Installed() {
+			if m.Installed(latest) {
 				cell1 = green(cell1)
 				localFormat = formatColor
 			} else if len(installed) > 0 {
@@ -37,17 +39,40 @@ func List(ctx context.Context) error {
 				localFormat = formatColor
 			}
 		}
-		fmt.Printf(localFormat, cell1, latest.Raw, installed)
+		fmt.Printf(localFormat, cell1, versionName(mv.LatestOf(sdkmgr.ChannelStable)), versionName(mv.LatestOf(sdkmgr.ChannelPre)), installed)
+	}
+	if len(m.SDKDirs()) > 1 {
+		return listRoots(ctx, m)
 	}
 	return nil
 }
 
-func installedVersions(vs []*Version) []string {
+// listRoots 配置了 SharedSDKDirs 时，列出已安装的版本所在的安装目录
+func listRoots(ctx context.Context, m *sdkmgr.Manager) error {
+	sdks, err := m.List(ctx)
+	if err != nil {
+		return err
+	}
+	format := "%-16s %-10s %s\n"
+	fmt.Println(strings.Repeat("-", 80))
+	fmt.Printf(format, "installed", "mode", "root")
+	fmt.Println(strings.Repeat("-", 80))
+	for _, s := range sdks {
+		mode := "writable"
+		if s.Shared {
+			mode = "read-only"
+		}
+		fmt.Printf(format, s.Version.RawFormatted(), mode, s.Root)
+	}
+	return nil
+}
+
+func installedVersions(m *sdkmgr.Manager, vs []*sdkmgr.Version) []string {
 	var result []string
 	for _, v := range vs {
-		if v.Installed() {
+		if m.Installed(v) {
 			name := v.RawFormatted()
-			if isLocked(v.Raw) {
+			if m.IsLocked(v) {
 				name += "(L)"
 			}
 			result = append(result, fmt.Sprintf("%-12s", name))
@@ -55,3 +80,10 @@ func installedVersions(vs []*Version) []string {
 	}
 	return result
 }
+
+func versionName(v *sdkmgr.Version) string {
+	if v == nil {
+		return ""
+	}
+	return v.Raw
+}
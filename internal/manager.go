@@ -0,0 +1,101 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"crypto/tls"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+// NewManager 依据配置文件和环境变量创建 sdkmgr.Manager
+func NewManager() (*sdkmgr.Manager, error) {
+	opts, err := managerOptions()
+	if err != nil {
+		return nil, err
+	}
+	return sdkmgr.New(opts)
+}
+
+// NewPlatformManager 创建安装其他平台 SDK 的 sdkmgr.Manager，即 install 的 --platform 和 --root 参数
+// platform 为空时为当前平台，root 为空时为 SDKDir，SDK 会安装到 {root}/go1.x.y.{goos}-{goarch}，
+// 版本列表和打包文件缓存和 NewManager 共用
+func NewPlatformManager(platform string, root string) (*sdkmgr.Manager, error) {
+	opts, err := managerOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts.Platform = sdkmgr.CurrentPlatform()
+	if len(platform) > 0 {
+		if opts.Platform, err = sdkmgr.ParsePlatform(platform); err != nil {
+			return nil, err
+		}
+	}
+	if len(root) > 0 {
+		opts.SDKDir = root
+		opts.SharedSDKDirs = nil
+	}
+	return sdkmgr.New(opts)
+}
+
+func managerOptions() (sdkmgr.Options, error) {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.Proxy = defaultConfig.getProxy()
+	tr.DialContext = (&net.Dialer{Timeout: 5 * time.Second}).DialContext
+	if defaultConfig.InsecureSkipVerify {
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	indexes, err := defaultConfig.getIndexes()
+	if err != nil {
+		return sdkmgr.Options{}, err
+	}
+	fetchers, err := defaultConfig.getFetchers()
+	if err != nil {
+		return sdkmgr.Options{}, err
+	}
+	return sdkmgr.Options{
+		SDKDir:        defaultConfig.getSDKDir(),
+		SharedSDKDirs: defaultConfig.SharedSDKDirs,
+		GOBIN:         GOBIN(),
+		DataDir:       DataDir(),
+		CacheDir:      defaultConfig.CacheDir,
+		CacheSize:     defaultConfig.getCacheSize(),
+		GoRepo:        defaultConfig.GoRepo,
+		Shim:          selfPath(),
+		Mirrors:       defaultConfig.getTarURLPrefix(),
+		Indexes:       indexes,
+		Fetchers:      fetchers,
+		HTTPClient: &http.Client{
+			Transport: tr,
+			Timeout:   10 * time.Minute,
+		},
+		InsecureSkipVerify: defaultConfig.InsecureSkipVerify,
+		Offline:            defaultConfig.Offline,
+		GoGit:              len(os.Getenv("Smart_Go_Dl_GoGit")) != 0,
+		Logger:             log.Default(),
+		Output:             os.Stderr,
+	}, nil
+}
+
+// SetOffline 开启离线模式，即 --offline，需要在 NewManager 之前调用
+func SetOffline() {
+	defaultConfig.Offline = true
+}
+
+// selfPath 当前程序的路径，会被链接为 $GOBIN/go1.x.y 等命令
+func selfPath() string {
+	if p := os.Getenv("_"); p != "" {
+		return p
+	}
+	if p, err := os.Executable(); err == nil {
+		return p
+	}
+	return os.Args[0]
+}
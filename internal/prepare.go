@@ -4,6 +4,15 @@
 
 package internal
 
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
 func Prepare1() error {
 	if err := ParserGOBIN(); err != nil {
 		return err
@@ -13,15 +22,75 @@ func Prepare1() error {
 }
 
 // Prepare2 在其他正式命令之前的预处理逻辑
-func Prepare2() error {
+func Prepare2(m *sdkmgr.Manager) {
 	logPrint("config", configPath())
 
 	printProxy()
-	dataDir := DataDir()
-	logPrint("data dir", dataDir)
+	logPrint("data dir", m.DataDir())
+}
 
-	if err := chdir(dataDir); err != nil {
-		return err
+// RefreshMode 更新版本列表的方式
+type RefreshMode int
+
+const (
+	// RefreshAuto 距离上次更新超过配置的 IndexRefreshInterval 时才更新
+	RefreshAuto RefreshMode = iota
+
+	// RefreshAlways 总是更新，即 --refresh
+	RefreshAlways
+
+	// RefreshNever 不更新，使用本地缓存的版本列表，即 --no-refresh
+	RefreshNever
+)
+
+// RefreshIndex 需要版本列表的命令执行前，按需更新版本列表
+// 更新失败时会继续使用本地缓存的版本列表，只有被中断时才会返回错误
+//
+// 无论是否更新，都会输出版本列表的更新时间，过旧时会给出提示
+func RefreshIndex(ctx context.Context, m *sdkmgr.Manager, mode RefreshMode) error {
+	defer printIndexAge(m)
+	if m.Offline() {
+		mode = RefreshNever
+	}
+	var err error
+	switch mode {
+	case RefreshNever:
+		return nil
+	case RefreshAlways:
+		err = m.Refresh(ctx)
+	default:
+		err = m.RefreshIfStale(ctx, defaultConfig.getIndexRefreshInterval())
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		logPrint("refresh", "failed, using cached version index:", err)
+	}
+	return nil
+}
+
+// staleIndexAge 版本列表超过此时间未更新时，会提示可能缺少新版本
+const staleIndexAge = 7 * 24 * time.Hour
+
+// printIndexAge 输出版本列表的更新时间，如 "index from 2025-11-17, 34 days old"
+func printIndexAge(m *sdkmgr.Manager) {
+	it := m.IndexTime()
+	if it.IsZero() {
+		logPrint("index", "never refreshed, using the embedded or cached version index")
+		if m.Offline() {
+			log.Println("warning: version index has never been refreshed, new Go releases may be missing")
+		}
+		return
+	}
+	age := time.Since(it)
+	days := int(age.Hours() / 24)
+	if days == 0 {
+		logPrint("index", fmt.Sprintf("index from %s, updated today", it.Format(time.DateOnly)))
+		return
+	}
+	logPrint("index", fmt.Sprintf("index from %s, %d days old", it.Format(time.DateOnly), days))
+	if age > staleIndexAge {
+		log.Printf("warning: version index is %d days old, new Go releases may be missing\n", days)
 	}
-	return Download()
 }
@@ -2,73 +2,337 @@ package internal
 
 import (
 	"context"
+	"debug/buildinfo"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"syscall"
 
+	"github.com/fsgo/cmdutil"
 	"github.com/fsgo/cmdutil/gosdk"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
 )
 
+// ExitInterrupted 收到 SIGINT、SIGTERM 信号被中断时的退出码
+const ExitInterrupted = 130
+
 var goCMDReg = regexp.MustCompile(`^go1\.\d+`)
 
 // TryRunGo 尝试运行 go 命令，如 go env
 func TryRunGo(ctx context.Context, name string) {
-	name = strings.TrimRight(filepath.Base(name), exe())
-	if name == "go" || name == "go.latest" {
-		closeFile := TrySetLogFile("go")
-		log.Println("TryRunGo：", name)
-		defer closeFile()
-		runLatest(ctx)
+	name = shimName(name)
+	if !isShimName(name) {
+		return
+	}
+	closeFile := TrySetLogFile("go")
+	log.Println("TryRunGo：", name)
+	defer closeFile()
+	readConfig()
+	m := mustNewManager()
+	log.SetFlags(0)
+
+	if isDownload(name, os.Args) {
+		download(ctx, m, name)
 	}
 
-	if goCMDReg.MatchString(name) {
-		closeFile := TrySetLogFile("go")
-		log.Println("TryRunGo：", name)
-		defer closeFile()
-		run(ctx, name)
+	r, err := resolveShim(ctx, m, name)
+	if err != nil {
+		log.Fatalln(err)
 	}
+	log.Println("TryRunGo：", name, "->", r.GOROOT, r.Reason)
+	// RunGo 结束后直接退出进程，所以需要在启动 go 命令之前完成，
+	// 都只读写本地的小文件，检查更新在独立的后台进程中执行，不会明显延迟 go 命令的启动
+	beforeRunGo(m, name, r)
+	gosdk.RunGo(ctx, r.GOROOT)
 }
 
-func runLatest(ctx context.Context) {
-	sd := &gosdk.SDK{
-		ExtDirs: []string{SDKRootDir()},
+// beforeRunGo 以别名运行 go 命令之前记录使用时间、提示和检查更新，都是尽力而为的，失败时不影响 go 命令
+func beforeRunGo(m *sdkmgr.Manager, name string, r *Resolution) {
+	if v, err := sdkmgr.ParseVersion(r.Version); err == nil {
+		_ = m.MarkUsed(v)
 	}
-	goBin := sd.Latest(ctx)
-	log.Println("runLatest, goBin=", goBin)
-	if goBin == "" {
-		log.Fatalln("not found go")
+	if name != "gotip" {
+		tryCheckUpdate(m, r.checkVersion)
 	}
-	root := filepath.Dir(filepath.Dir(goBin))
-	gosdk.RunGo(ctx, root)
 }
 
-func run(ctx context.Context, version string) {
-	log.SetFlags(0)
+func mustNewManager() *sdkmgr.Manager {
+	m, err := NewManager()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return m
+}
+
+// shimName 以别名运行时的名称，如 /usr/local/bin/go1.22.exe 为 go1.22
+func shimName(name string) string {
+	return strings.TrimRight(filepath.Base(name), exe())
+}
+
+func isShimName(name string) bool {
+	return name == "go" || name == "go.latest" || name == "gotip" || goCMDReg.MatchString(name)
+}
+
+// Resolution 以别名运行时选择的 SDK 和原因，见 TryRunGo、Which
+type Resolution struct {
+	// Name 别名，如 go、go.latest、go1.22、gotip
+	Name string `json:"name"`
+
+	// Version 选择的版本，如 go1.22.5
+	Version string `json:"version"`
+
+	GOROOT string `json:"goroot"`
+	GoBin  string `json:"go_bin"`
+
+	// Source 选择的依据，如 latest、minor，见 source* 常量
+	Source string `json:"source"`
 
-	sd := &gosdk.SDK{
-		ExtDirs: []string{SDKRootDir()},
+	// Reason 选择的原因
+	Reason string `json:"reason"`
+
+	// Notes 当前目录的项目中指定的 go 版本的说明，如 go.mod 中的 toolchain，只由 which 填写，不影响选择的版本
+	Notes []string `json:"notes,omitempty"`
+
+	// checkVersion 检查新版本时使用的版本，见 tryCheckUpdate
+	checkVersion string
+}
+
+// Resolution.Source 的取值
+const (
+	// sourceLatest go、go.latest 使用 $PATH 和安装目录中最新的 go
+	sourceLatest = "latest"
+
+	// sourceMinor go1.x 使用已安装的最新修订版本
+	sourceMinor = "minor"
+
+	// sourcePre go1.x 没有已安装的正式版本，使用最新的预览版本
+	sourcePre = "pre"
+
+	// sourceExact go1.x.y 等使用同名的版本
+	sourceExact = "exact"
+
+	// sourceTip gotip 使用从源码构建的版本
+	sourceTip = "tip"
+)
+
+// resolveShim 查找以别名 name 运行时使用的 SDK，TryRunGo 和 which 子命令共用，
+// 不依赖当前目录，go、go.latest 都使用 $PATH 和安装目录中最新的 go
+func resolveShim(ctx context.Context, m *sdkmgr.Manager, name string) (*Resolution, error) {
+	r := &Resolution{Name: name, checkVersion: name}
+	switch {
+	case name == "go" || name == "go.latest":
+		sd := &gosdk.SDK{
+			ExtDirs: m.SDKDirs(),
+		}
+		list := sd.List(ctx)
+		log.Println("runLatest, list=", list)
+		if len(list) == 0 {
+			return nil, errors.New("not found go")
+		}
+		goBin := list[0]
+		r.GoBin = goBin
+		r.GOROOT = filepath.Dir(filepath.Dir(goBin))
+		r.Version = filepath.Base(r.GOROOT)
+		r.checkVersion = r.Version
+		where := "in $PATH"
+		for _, dir := range m.SDKDirs() {
+			if filepath.Dir(r.GOROOT) == dir {
+				where = "in " + dir
+			}
+		}
+		if v, err := sdkmgr.ParseVersion(r.Version); err != nil {
+			// 如 /usr/local/go，使用 go 命令的编译信息中的版本
+			if bi, err1 := buildinfo.ReadFile(goBin); err1 == nil {
+				r.Version, _, _ = strings.Cut(bi.GoVersion, " ")
+			}
+		} else {
+			r.Version = v.Raw
+		}
+		r.Source = sourceLatest
+		r.Reason = "the newest go " + where + ", among the go in $PATH and " + strings.Join(m.SDKDirs(), ", ")
+		return r, nil
+	case name == "gotip":
+		sdk, err := m.Resolve(ctx, "gotip")
+		if err != nil || !m.Unpacked(sdk.Version) {
+			return nil, errors.New("gotip: not installed. Run 'gotip download' or 'smart-go-dl install gotip'")
+		}
+		r.setSDK(sdk)
+		r.Source = sourceTip
+		r.Reason = "gotip built from source"
+		if info, err := m.TipInfo(); err == nil {
+			r.Reason += " at " + info.Ref + " " + info.Commit
+		}
+		return r, nil
+	case goCMDReg.MatchString(name):
+		// 如 go1.22、go1.20 为该次要版本已安装的最新版本，go1.22.5 不会匹配到变体版本 go1.22.5-acme
+		sdk, err := m.ResolveLink(ctx, name)
+		v, errV := sdkmgr.ParseVersion(name)
+		minorOnly := errV == nil && !v.IsVariant() && v.Upstream().Raw == v.Lang()
+		r.Source = sourceExact
+		if minorOnly {
+			r.Source = sourceMinor
+		}
+		if err != nil && minorOnly {
+			// 如 install go1.26rc 后 $GOBIN/go1.26 链接到 go1.26rc1，没有正式版本时使用最新的预览版本
+			if sdk, err = m.Resolve(ctx, fmt.Sprintf(">=%sbeta1, <=%s", v.Raw, v.Raw)); err == nil {
+				r.Source = sourcePre
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("not found %s", name)
+		}
+		if !m.Unpacked(sdk.Version) {
+			return nil, fmt.Errorf("%s: not downloaded. Run '%s download' to install to %v", name, name, sdk.GOROOT)
+		}
+		r.setSDK(sdk)
+		r.checkVersion = name
+		switch r.Source {
+		case sourcePre:
+			r.Reason = "the newest installed pre-release of " + name + ", no stable version installed"
+		case sourceMinor:
+			r.Reason = "the newest installed patch version of " + name
+		default:
+			r.Reason = "the installed " + sdk.Version.Raw
+		}
+		if sdk.Shared {
+			r.Reason += ", in the read-only " + sdk.Root
+		}
+		return r, nil
+	default:
+		return nil, fmt.Errorf("%q is not a command of smart-go-dl, expect go, go.latest, gotip or go1.x", name)
 	}
+}
 
-	goBin := sd.Find(ctx, version)
-	if goBin == "" {
-		log.Fatalln("not found", version)
+func (r *Resolution) setSDK(sdk *sdkmgr.SDK) {
+	r.Version, r.GOROOT, r.GoBin = sdk.Version.Raw, sdk.GOROOT, sdk.GoBin
+	r.checkVersion = sdk.Version.Raw
+}
+
+// isDownload 是否以别名运行 golang.org/dl 定义的 download 子命令：go1.x download、gotip download [branch|commit]，
+// go、go.latest 没有 download 子命令，参数个数不同时（如 go1.22 download -x）也作为普通的 go 命令执行
+func isDownload(name string, args []string) bool {
+	if len(args) < 2 || args[1] != "download" {
+		return false
 	}
+	switch {
+	case name == "gotip":
+		return len(args) <= 3
+	case goCMDReg.MatchString(name):
+		return len(args) == 2
+	default:
+		return false
+	}
+}
 
-	if len(os.Args) == 2 && os.Args[1] == "download" {
-		loadConfig()
-		if err := installByArchive(version); err != nil {
-			log.Fatalf("%s: install failed: %v", version, err)
+// download 以别名运行时的 download 子命令，如 "go1.22.5 download"、"gotip download [branch|commit]"
+func download(ctx context.Context, m *sdkmgr.Manager, name string) {
+	if name == "gotip" {
+		ref := ""
+		if len(os.Args) > 2 {
+			ref = os.Args[2]
+		}
+		sctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		if _, err := m.InstallTip(sctx, ref); err != nil {
+			log.Fatalf("gotip: build failed: %v", err)
 		}
 		os.Exit(0)
 	}
+	v, err := sdkmgr.ParseVersion(name)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if err = m.Download(ctx, v); err != nil {
+		log.Fatalf("%s: install failed: %v", name, err)
+	}
+	os.Exit(0)
+}
 
-	root := filepath.Dir(filepath.Dir(goBin))
+// Which 输出以别名 name 运行时使用的 SDK 和原因，即 which 子命令，和 TryRunGo 使用相同的逻辑
+func Which(ctx context.Context, m *sdkmgr.Manager, name string, asJSON bool) error {
+	if len(name) == 0 {
+		name = "go"
+	}
+	r, err := resolveShim(ctx, m, shimName(name))
+	if err != nil {
+		return err
+	}
+	if wd, err := os.Getwd(); err == nil {
+		r.Notes = projectNotes(wd)
+	}
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+	format := "%-8s %s\n"
+	fmt.Printf(format, "name", r.Name)
+	fmt.Printf(format, "version", r.Version)
+	fmt.Printf(format, "goroot", r.GOROOT)
+	fmt.Printf(format, "go", r.GoBin)
+	fmt.Printf(format, "source", r.Source)
+	fmt.Printf(format, "reason", r.Reason)
+	for _, note := range r.Notes {
+		fmt.Printf(format, "note", note)
+	}
+	return nil
+}
 
-	if _, err := os.Stat(filepath.Join(root, unpackedOkay)); err != nil {
-		log.Fatalf("%s: not downloaded. Run '%s download' to install to %v", version, version, root)
+// projectNotes 目录 dir 所在的项目中指定的 go 版本的说明，smart-go-dl 不会依据它们选择版本
+func projectNotes(dir string) []string {
+	var notes []string
+	if tc := sdkmgr.FindToolchain(dir); tc != nil {
+		notes = append(notes, "toolchain "+tc.Version+" in "+tc.File+
+			" is the minimum go version, the go command switches to a newer toolchain itself as GOTOOLCHAIN allows")
 	}
+	if pin := sdkmgr.FindPin(dir); pin != nil {
+		notes = append(notes, fmt.Sprintf("%q in %s is not used by smart-go-dl", pin.Version, pin.File))
+	}
+	return notes
+}
 
-	gosdk.RunGo(ctx, root)
+// Exec 使用已安装的、满足版本约束的 go 执行命令，返回 go 命令的退出码
+//
+// version: 版本号或者版本约束，如 go1.22、go1.22.5、>=1.21、stable
+func Exec(ctx context.Context, m *sdkmgr.Manager, version string, args []string) (int, error) {
+	if len(version) == 0 {
+		return 2, errors.New("version is required")
+	}
+	sdk, err := m.Resolve(ctx, version)
+	if err != nil {
+		return 2, err
+	}
+	_ = m.MarkUsed(sdk.Version)
+	root := sdk.GOROOT
+	cmd := exec.CommandContext(ctx, sdk.GoBin, args...)
+	setInterrupt(cmd)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	oe := &cmdutil.OSEnv{}
+	oe.MustSet("GOROOT", root)
+	oe.MustInsert("PATH", filepath.Join(root, "bin"))
+	cmd.Env = oe.Environ()
+
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		return ExitInterrupted, ctx.Err()
+	}
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		return ee.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
 }
@@ -0,0 +1,150 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsgo/fst"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+// testManager 创建使用临时目录的 Manager，并安装 versions，每个 SDK 只有 bin/go 和标记文件
+func testManager(t *testing.T, versions ...string) *sdkmgr.Manager {
+	t.Helper()
+	dir := t.TempDir()
+	m, err := sdkmgr.New(sdkmgr.Options{
+		SDKDir:  filepath.Join(dir, "sdk"),
+		DataDir: filepath.Join(dir, "data"),
+		GOBIN:   filepath.Join(dir, "bin"),
+	})
+	fst.NoError(t, err)
+	for _, version := range versions {
+		v, err := sdkmgr.ParseVersion(version)
+		fst.NoError(t, err)
+		bin := filepath.Join(m.GOROOT(v), "bin")
+		fst.NoError(t, os.MkdirAll(bin, 0755))
+		fst.NoError(t, os.WriteFile(filepath.Join(bin, "go"+exe()), []byte("go"), 0755))
+		fst.NoError(t, os.WriteFile(filepath.Join(m.GOROOT(v), ".unpacked-success"), nil, 0644))
+	}
+	return m
+}
+
+func TestResolveShim_minor(t *testing.T) {
+	ctx := context.Background()
+	m := testManager(t, "go1.25.3", "go1.26rc1", "go1.26rc2", "go1.27rc1", "go1.27.0")
+
+	tests := []struct {
+		name   string
+		want   string
+		source string
+	}{
+		// 只安装了预览版本时，使用最新的预览版本
+		{name: "go1.26", want: "go1.26rc2", source: sourcePre},
+		// 有正式版本时不使用预览版本
+		{name: "go1.27", want: "go1.27.0", source: sourceMinor},
+		{name: "go1.25", want: "go1.25.3", source: sourceMinor},
+		{name: "go1.26rc1", want: "go1.26rc1", source: sourceExact},
+		{name: "go1.25.3", want: "go1.25.3", source: sourceExact},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := resolveShim(ctx, m, tt.name)
+			fst.NoError(t, err)
+			fst.Equal(t, tt.want, r.Version)
+			fst.Equal(t, tt.source, r.Source)
+			fst.Equal(t, filepath.Join(m.SDKDir(), tt.want), r.GOROOT)
+		})
+	}
+
+	_, err := resolveShim(ctx, m, "go1.24")
+	fst.Error(t, err)
+}
+
+func TestIsDownload(t *testing.T) {
+	fst.True(t, isDownload("go1.22.5", []string{"go1.22.5", "download"}))
+	fst.True(t, isDownload("gotip", []string{"gotip", "download"}))
+	fst.True(t, isDownload("gotip", []string{"gotip", "download", "release-branch.go1.23"}))
+	fst.False(t, isDownload("go", []string{"go", "download"}))
+	fst.False(t, isDownload("go.latest", []string{"go.latest", "download"}))
+	fst.False(t, isDownload("go1.22", []string{"go1.22", "download", "-x"}))
+	fst.False(t, isDownload("go1.22", []string{"go1.22", "mod", "download"}))
+	fst.False(t, isDownload("go1.22", []string{"go1.22"}))
+}
+
+func TestResolveShim_go(t *testing.T) {
+	ctx := context.Background()
+	m := testManager(t, "go1.22.5", "go1.23.1", "gotip")
+
+	// go、go.latest 不依赖当前目录，都使用 $PATH 和安装目录中最新的 go
+	if goBin, err := exec.LookPath("go"); err == nil {
+		t.Setenv("HOME", t.TempDir())
+		t.Setenv("PATH", filepath.Dir(goBin))
+		for _, name := range []string{"go", "go.latest"} {
+			r, err := resolveShim(ctx, m, name)
+			fst.NoError(t, err)
+			fst.Equal(t, sourceLatest, r.Source)
+			fst.Equal(t, goBin, r.GoBin)
+		}
+	}
+
+	r, err := resolveShim(ctx, m, "gotip")
+	fst.NoError(t, err)
+	fst.Equal(t, sourceTip, r.Source)
+	fst.Equal(t, filepath.Join(m.SDKDir(), "gotip"), r.GOROOT)
+}
+
+func TestProjectNotes(t *testing.T) {
+	fst.Empty(t, projectNotes(t.TempDir()))
+
+	project := t.TempDir()
+	fst.NoError(t, os.WriteFile(filepath.Join(project, "go.mod"), []byte("module example.com/a\n\ngo 1.22\n\ntoolchain go1.22.5\n"), 0644))
+	fst.NoError(t, os.WriteFile(filepath.Join(project, sdkmgr.PinFile), []byte("~1.23\n"), 0644))
+	sub := filepath.Join(project, "internal", "a")
+	fst.NoError(t, os.MkdirAll(sub, 0755))
+	notes := projectNotes(sub)
+	fst.Equal(t, 2, len(notes))
+	fst.Contains(t, notes[0], "toolchain go1.22.5 in "+filepath.Join(project, "go.mod"))
+	fst.Contains(t, notes[1], "not used by smart-go-dl")
+}
+
+func TestReadConfig(t *testing.T) {
+	// 以 go 别名运行时只读取配置，配置文件不存在时也不会创建
+	t.Setenv("HOME", t.TempDir())
+	fst.False(t, readConfig())
+	_, err := os.Stat(configPath())
+	fst.True(t, os.IsNotExist(err))
+}
+
+func TestAddShimInfo(t *testing.T) {
+	ctx := context.Background()
+	m := testManager(t, "go1.22.5", "go1.23.1")
+	project := t.TempDir()
+	fst.NoError(t, os.WriteFile(filepath.Join(project, sdkmgr.PinFile), []byte("~1.22\n"), 0644))
+
+	// 项目中指定的版本只是说明
+	info, err := m.Info(ctx, "go1.22.5")
+	fst.NoError(t, err)
+	addShimInfo(ctx, m, info, project)
+	fst.NotNil(t, info.Pin)
+	fst.Equal(t, "~1.22", info.Pin.Version)
+	fst.Equal(t, filepath.Join(project, sdkmgr.PinFile), info.Pin.File)
+
+	info, err = m.Info(ctx, "go1.23.1")
+	fst.NoError(t, err)
+	addShimInfo(ctx, m, info, project)
+	fst.Nil(t, info.Pin)
+
+	// toolchain 指令是最低版本，只有同名时才是
+	fst.NoError(t, os.WriteFile(filepath.Join(project, "go.mod"), []byte("module example.com/a\n\ngo 1.22\n\ntoolchain go1.23.1\n"), 0644))
+	addShimInfo(ctx, m, info, project)
+	fst.NotNil(t, info.Pin)
+	fst.Equal(t, filepath.Join(project, "go.mod"), info.Pin.File)
+}
@@ -0,0 +1,42 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+// Serve 作为其他 smart-go-dl 的镜像提供打包文件和版本列表，直到 ctx 取消
+func Serve(ctx context.Context, m *sdkmgr.Manager, listen string) error {
+	if len(listen) == 0 {
+		listen = ":8080"
+	}
+	srv := &http.Server{
+		Addr: listen,
+		Handler: m.Handler(ctx, sdkmgr.ServeOptions{
+			IndexMaxAge: defaultConfig.getIndexRefreshInterval(),
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		sctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(sctx)
+	}()
+	logPrint("serve", "listening on", listen, ", cache dir:", m.CacheDir())
+	logPrint("serve", "clients can set TarURLPrefix = \"http://{host}"+listen+"/\"")
+	err := srv.ListenAndServe()
+	// 收到 SIGINT、SIGTERM 停止服务是正常退出
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
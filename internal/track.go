@@ -0,0 +1,105 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+// TrackConfig 版本跟踪策略，执行 "update" / "update all" 时生效
+type TrackConfig struct {
+	// Stable 总是保持最新的 N 个正式次要版本，可选
+	// 如 2 表示有 go1.26 发布后，会自动安装 go1.26，跟踪范围为 go1.26 和 go1.25
+	Stable int
+
+	// Pre 是否跟踪最新的预览版本（beta、rc），可选
+	// 只有比最新正式次要版本更新的预览版本才会被安装，如 go1.27rc1
+	Pre bool
+
+	// Retire 是否删除不在跟踪范围内的次要版本，可选，被 lock 的版本不会被删除
+	// 需要同时配置 Stable，只配置了 Pre 时不会删除任何版本，否则会删除所有的正式版本
+	Retire bool
+}
+
+func (tc *TrackConfig) enabled() bool {
+	return tc.Stable > 0 || tc.Pre
+}
+
+// retire 是否删除不在跟踪范围内的次要版本，Stable 为 0 时跟踪范围内没有正式版本，不删除
+func (tc *TrackConfig) retire() bool {
+	return tc.Retire && tc.Stable > 0
+}
+
+// tracked 依据跟踪策略筛选出需要保持安装的次要版本
+func (tc *TrackConfig) tracked(versions sdkmgr.Versions) sdkmgr.Versions {
+	var result sdkmgr.Versions
+	var stable int
+	for _, mv := range versions {
+		if mv.NormalizedVersion == "gotip" {
+			continue
+		}
+		if mv.Latest().IsNormal() {
+			if stable < tc.Stable {
+				result = append(result, mv)
+			}
+			stable++
+			continue
+		}
+		// versions 是按照版本倒序排列的，只有在所有正式版本之前的才是最新的预览版本
+		if tc.Pre && stable == 0 {
+			result = append(result, mv)
+		}
+	}
+	return result
+}
+
+// trackVersions 安装跟踪范围内新发布的次要版本，并按照配置删除超出跟踪范围的次要版本
+func trackVersions(ctx context.Context, m *sdkmgr.Manager, versions sdkmgr.Versions) error {
+	tc := defaultConfig.Track
+	if !tc.enabled() {
+		return nil
+	}
+	tracked := tc.tracked(versions)
+
+	var failed []string
+	for _, mv := range tracked {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if m.MinorInstalled(mv) {
+			continue
+		}
+		logPrint("track", "install new version", mv.NormalizedVersion)
+		if _, err := m.Install(ctx, mv.NormalizedVersion, sdkmgr.ChannelAny); err != nil {
+			logPrint("track", mv.NormalizedVersion, "failed:", err)
+			failed = append(failed, mv.NormalizedVersion)
+		}
+		fmt.Fprint(os.Stderr, "\n")
+	}
+
+	if tc.Retire && !tc.retire() {
+		logPrint("track", "Retire ignored, it requires Stable > 0")
+	}
+	if tc.retire() {
+		for _, mv := range versions {
+			if mv.NormalizedVersion == "gotip" || !m.MinorInstalled(mv) || tracked.Get(mv.NormalizedVersion) != nil {
+				continue
+			}
+			if err := m.Retire(ctx, mv); err != nil {
+				logPrint("track", "retire", mv.NormalizedVersion, "failed:", err)
+				failed = append(failed, mv.NormalizedVersion)
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("track %q failed", failed)
+	}
+	return nil
+}
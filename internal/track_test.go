@@ -0,0 +1,71 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/fsgo/fst"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
+)
+
+func TestTrackConfig_tracked(t *testing.T) {
+	vs := sdkmgr.ParseVersions([]string{
+		"go1.27rc1", "go1.27beta1",
+		"go1.26rc1", "go1.26.0", "go1.26.1",
+		"go1.25.0", "go1.25.3",
+		"go1.24.0",
+		"gotip",
+	})
+
+	names := func(vs sdkmgr.Versions) []string {
+		var result []string
+		for _, mv := range vs {
+			result = append(result, mv.NormalizedVersion)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name string
+		tc   TrackConfig
+		want []string
+	}{
+		{
+			name: "stable 2",
+			tc:   TrackConfig{Stable: 2},
+			want: []string{"go1.26", "go1.25"},
+		},
+		{
+			name: "pre",
+			tc:   TrackConfig{Pre: true},
+			want: []string{"go1.27"},
+		},
+		{
+			name: "stable 1 and pre",
+			tc:   TrackConfig{Stable: 1, Pre: true},
+			want: []string{"go1.27", "go1.26"},
+		},
+		{
+			name: "disabled",
+			tc:   TrackConfig{},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fst.Equal(t, tt.want, names(tt.tc.tracked(vs)))
+		})
+	}
+}
+
+func TestTrackConfig_retire(t *testing.T) {
+	fst.True(t, (&TrackConfig{Stable: 2, Retire: true}).retire())
+	fst.False(t, (&TrackConfig{Stable: 2}).retire())
+	// 只跟踪预览版本时不删除，否则所有的正式版本都不在跟踪范围内
+	fst.False(t, (&TrackConfig{Pre: true, Retire: true}).retire())
+	fst.False(t, (&TrackConfig{Retire: true}).retire())
+}
@@ -6,42 +6,76 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+
+	"github.com/fsgo/smart-go-dl/sdkmgr"
 )
 
 // Update 更新 go 版本，version 支持多种格式
-// 如 go1.16、go1.16.1、all
-func Update(ctx context.Context, version string) error {
-	defer installGoLatestBin(ctx)
+// 如 go1.16、go1.16.1、all，或者版本约束，如 ~1.22、stable，会更新其所在的次要版本
+// 更新全部版本时，若配置了版本跟踪策略，还会安装新发布的次要版本
+func Update(ctx context.Context, m *sdkmgr.Manager, version string) error {
+	defer m.LinkLatest(ctx)
 	if version == "all" || len(version) == 0 {
-		return updateAll(ctx)
+		return updateAll(ctx, m)
+	}
+	if name, _, _ := strings.Cut(version, "@"); name == "gotip" {
+		_, err := m.Install(ctx, version, sdkmgr.ChannelAny)
+		return err
+	}
+	if sdkmgr.IsConstraint(version) {
+		v, err := m.ResolveRelease(ctx, version)
+		if err != nil {
+			return err
+		}
+		version = v.Normalized
+	}
+	return update(ctx, m, version)
+}
+
+// updateTip 使用上次构建时的分支更新 gotip，没有新的提交时不会重新构建
+func updateTip(ctx context.Context, m *sdkmgr.Manager) error {
+	_, err := m.InstallTip(ctx, "")
+	if err != nil {
+		logPrint("update", "gotip failed:", err)
+	} else {
+		logPrint("update", "gotip success")
 	}
-	return update(ctx, version)
+	return err
 }
 
-func update(ctx context.Context, version string) error {
-	if err := Install(ctx, version); err != nil {
+func update(ctx context.Context, m *sdkmgr.Manager, version string) error {
+	if _, err := m.Install(ctx, version, sdkmgr.ChannelAny); err != nil {
 		return err
 	}
-	return Clean(ctx, version)
+	return m.Clean(ctx, version, sdkmgr.RemoveOptions{})
 }
 
-func updateAll(ctx context.Context) error {
-	versions, err := LastVersions(ctx)
+func updateAll(ctx context.Context, m *sdkmgr.Manager) error {
+	versions, err := m.Versions(ctx)
 	if err != nil {
 		return err
 	}
 
 	var failed []string
 	for _, mv := range versions {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
 		if mv.NormalizedVersion == "gotip" {
-			logPrint("update", "skip gotip, you can update it by 'gotip download'")
-			fmt.Fprint(os.Stderr, "\n")
+			if m.MinorInstalled(mv) {
+				if err = updateTip(ctx, m); err != nil {
+					failed = append(failed, mv.NormalizedVersion)
+				}
+				fmt.Fprint(os.Stderr, "\n")
+			}
 			continue
 		}
-		if mv.Installed() {
-			if err = update(ctx, mv.NormalizedVersion); err != nil {
+		if m.MinorInstalled(mv) {
+			if err = update(ctx, m, mv.NormalizedVersion); err != nil {
 				logPrint("update", mv.NormalizedVersion, "failed:", err)
 				failed = append(failed, mv.NormalizedVersion)
 			} else {
@@ -50,8 +84,9 @@ func updateAll(ctx context.Context) error {
 			fmt.Fprint(os.Stderr, "\n")
 		}
 	}
+	errTrack := trackVersions(ctx, m, versions)
 	if len(failed) > 0 {
-		return fmt.Errorf("update %q failed", failed)
+		return errors.Join(fmt.Errorf("update %q failed", failed), errTrack)
 	}
-	return nil
+	return errTrack
 }
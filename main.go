@@ -12,25 +12,72 @@ import (
 	"io"
 	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/fsgo/smart-go-dl/internal"
+	"github.com/fsgo/smart-go-dl/sdkmgr"
 )
 
 var helpMessage = `
 smart-go-dl subCommand [options]
 
 SubCommands:
+    Where a version is accepted, a constraint can be used too:
+        >=1.21 | >1.21.3 | <=1.22 | <1.23 | =1.22.5 | ~1.22 | 1.22.x
+        latest | stable | oldstable | ">=1.21,<1.23"
+        pre-releases (beta, rc) only match when the constraint names one, eg: ">=1.26rc1"
+
     install {go1.x} :
         install the latest go1.x, 'x' must be a number, x >= 5
           eg: "install go1.25", then you can run "go1.25"
         install the specified version:
           eg: install go1.25.0 | go1.25.2 | gotip
+        install the latest beta or rc version:
+          eg: install go1.26rc | install go1.26 --pre
+        install a local archive or one from file://, http:// or https:// url, exactly as supplied,
+        the version is read from its VERSION file:
+          eg: install ./go1.22.5.linux-amd64.tar.gz | install https://example.com/go1.22.5.linux-amd64.tar.gz
+        build gotip from source, with a branch, tag or commit, default is master or the last one used:
+          eg: install gotip | install gotip@release-branch.go1.23 | install gotip@3f4ceb0
+          the go repository ('GoRepo' in app.toml) is kept in {DataDir}/go.git and fetched incrementally,
+          the latest installed go which is new enough is used as GOROOT_BOOTSTRAP.
+          "gotip download [branch|commit]" works too.
+        when there is no binary archive for the current platform, go1.x.y.src.tar.gz is downloaded
+          and built by make.bash, with the latest installed go which is new enough as GOROOT_BOOTSTRAP.
+        options:
+          --stable : install stable version only, refuse beta and rc
+          --pre    : install the latest beta or rc version
+          --platform {os/arch} : install the SDK for another platform, eg: linux/arm64, linux/armv6l, windows/386
+          --root {dir}         : install into {dir} instead of SDKDir
+            with --platform or --root, the SDK is installed into {dir}/go1.x.y.{os}-{arch} without links in $GOBIN,
+            eg: install go1.22 --platform linux/arm64 --root ./out
+
+    build {go1.x.y} --patch {file} --name {go1.x.y-name} :
+        apply patches to go1.x.y and rebuild it as a variant with its own GOROOT and $GOBIN/{go1.x.y-name},
+        the source is copied from the installed go1.x.y, or downloaded as go1.x.y.src.tar.gz.
+        --patch can be repeated, and accepts globs. variants are listed, updated and cleaned separately,
+        as minor version {go1.x-name}, eg: "clean go1.22" keeps go1.22.5-acme, "clean go1.22-acme" cleans it.
+          eg: build go1.22.5 --patch ./patches/*.diff --name go1.22.5-acme
     
-    clean {go1.x} :
+    adopt {dir} [--move] :
+        adopt a go installation not installed by smart-go-dl, eg: /usr/local/go, ~/sdk/go1.22.5 of golang.org/dl,
+        the version is read from its VERSION file and the buildinfo of bin/go.
+        by default it is used in place via the link {SDKDir}/go1.x.y -> {dir}, remove and clean only delete the link,
+        --move moves it into {SDKDir}/go1.x.y and writes the manifest.
+        $GOBIN/go1.x.y is created, golang.org/dl wrappers are replaced.
+          eg: adopt /usr/local/go | adopt ~/sdk/go1.22.5 --move
+    adopt --scan [--dry-run] [dir...] :
+        find go installations in the usual locations (and dirs) and adopt them, --dry-run lists them only:
+          ~/sdk/go1.*, /usr/local/go, /usr/lib/go-1.*, /usr/lib/golang, the go in $PATH, ...
+
+    clean {go1.x} [--force] :
         clean up expired go versions.
         lower than the latest version will be removed.
         it will remove $GOBIN/{go1.x.y} and $HOME/sdk/{go1.x.y}
+        directories without the manifest {GOROOT}/.smart-go-dl.json, which every install writes,
+        are not installed by smart-go-dl and are skipped, unless --force.
         eg: "clean go1.15"
     
     lock {go1.x.y} :
@@ -42,16 +89,86 @@ SubCommands:
     update {go1.x} / all :
         alias of  "clean {go1.x}" && "install {go1.x}"
         "all": update all installed go versions, eg: "update all" or "update"
+               gotip is rebuilt when its branch has new commits
+               with [Track] in app.toml, new minor versions are installed automatically
 
-    remove {go1.x.y} :
+    remove {go1.x.y} [--force] :
         remove patch version like 'go1.25.3'
+        refuses to remove directories without the manifest {GOROOT}/.smart-go-dl.json, unless --force.
+        older smart-go-dl installs with {GOROOT}/.unpacked-success are removed when $GOBIN/go1.x.y is smart-go-dl,
+        golang.org/dl installs need 'adopt' first.
     
+    cache list | prune | clear :
+        manage the cache of downloaded archives, keyed by sha256, installs read from it first.
+        'CacheDir' and 'CacheSize' (default 4GB) in app.toml, the least recently used are evicted first.
+          list  : list cached archives
+          prune : evict archives until the cache fits 'CacheSize'
+          clear : remove all cached archives
+
+    serve [--listen :8080] :
+        act as a mirror for other smart-go-dl clients in the LAN, serving the archive cache and version list:
+          /go1.x.y.os-arch.tar.gz, /go1.x.y.src.tar.gz, /go1.x.y.os-arch.tar.gz.sha256, /SHA256SUMS
+          and /?mode=json (go.dev format)
+        archives not in the cache are fetched from the configured sources and kept in the cache.
+        on clients: TarURLPrefix = "http://{host}:8080/" and Indexes = ["feed:http://{host}:8080/?mode=json"]
+
+    bundle create --versions {go1.x,...} [--platforms {os/arch,...}] -o {file} :
+        package the archives, their SHA256SUMS and a snapshot of the version list into one tar file,
+        for machines without network. platforms default to the current one.
+          eg: bundle create --versions go1.22,go1.23 --platforms linux/amd64,linux/arm64 -o bundle.tar
+
+    bundle import {file} :
+        verify and import a bundle, then install its versions for the current platform, no network needed.
+        imported archives are kept in {DataDir}/bundle and used by later installs.
+          eg: bundle import bundle.tar
+
+    Options for install, update, clean, list, info, check-update and bundle create:
+        --refresh    : refresh the version index before running
+        --no-refresh : use the cached version index, never refresh it
+        --offline    : never touch the network, install from local archives only ('Offline' in app.toml)
+        by default the version index is refreshed when older than 'IndexRefreshInterval' (1m) in app.toml,
+        other subcommands like lock, remove, exec only use local data and never refresh it.
+
+    exec {version} [args...] :
+        run an installed go which matches the version or constraint.
+          eg: exec go1.22 version | exec ">=1.21,<1.23" test ./... | exec stable env
+
+    info {version} [--json] :
+        show everything known about a version: installed or not, GOROOT and its root, size on disk,
+        install time, source and sha256 from the manifest, lock status, last used time,
+        the $GOBIN links which run it (go and go.latest as resolved by which), whether the current project
+        names it (toolchain in go.mod, .go-version), and the newer patch version in the version index.
+          eg: info go1.22.5 | info go1.22 --json
+
+    which [go|go.latest|go1.x|go1.x.y|gotip] [--json] :
+        print the SDK which $GOBIN/{name} runs, with its GOROOT, go binary, the source (latest, minor, pre, exact, tip)
+        and the reason, using the same code as running the command itself, plus notes about the toolchain in
+        go.mod/go.work and .go-version of the current project, which never change the choice.
+        name defaults to go, "resolve" is an alias.
+          eg: which | which go1.22 | which go.latest --json
+
     list :
-        list all go versions that can be installed.
+        list all go versions that can be installed,
+        with the latest stable and pre-release (beta, rc) of each minor version.
 
     fix :
         fix links.
 
+    doctor [--fix] [--json] :
+        check the whole installation and print a checklist: links in $GOBIN (dangling, wrong, or to a moved smart-go-dl),
+        $GOBIN/go1.x links to the newest patch, incompletely unpacked SDKs, leftover archives and temporary directories,
+        the golang_dl checkout, $GOBIN in $PATH and other go before it, and whether app.toml can be parsed.
+        --fix repairs what can be repaired automatically, exits with 1 when problems remain.
+          eg: doctor | doctor --fix | doctor --json
+
+    check-update :
+        check whether installed go versions have new patch versions.
+        with 'CheckUpdateInterval' in app.toml, it runs in background when running 'go' or 'go1.x',
+        and prints a notice like "go1.22.6 available, run smart-go-dl update go1.22".
+
+Exit Code :
+    130 : interrupted by SIGINT or SIGTERM, downloads and temporary files are cleaned up
+
 Self-Update :
           go install github.com/fsgo/smart-go-dl@latest
 
@@ -69,59 +186,165 @@ func init() {
 }
 
 func main() {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	args := stringSlice(os.Args)
 
 	log.SetOutput(io.Discard)
-	// 当以 go 别名运行
-	internal.TryRunGo(ctx, args.get(0))
+	// 当以 go 别名运行，信号由 go 命令自己处理
+	internal.TryRunGo(context.Background(), args.get(0))
+
+	// 收到 SIGINT、SIGTERM 时取消 ctx，正在进行的下载、解压、git 等都会停止并清理
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
 
 	if err := internal.Prepare1(); err != nil {
 		log.SetOutput(os.Stderr)
 		log.Fatalln(err)
 	}
 
+	// exec 只使用已安装的版本，不需要更新版本列表，也不输出日志
+	if args.get(1) == "exec" {
+		code, err := execGo(ctx, args.get(2), args[min(3, len(args)):])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "[smart-go-dl] error: exec failed,", err)
+		}
+		os.Exit(code)
+	}
+
 	log.SetOutput(os.Stderr)
 	closeFile := internal.TrySetLogFile("default")
 	defer closeFile()
 
 	flag.Parse()
 
-	if err := internal.Prepare2(); err != nil {
-		log.Fatalln(err)
-	}
-
 	if len(args) < 2 || args.get(1) == "help" {
 		flag.Usage()
 		return
 	}
 
-	var err error
+	fs := newFlagSet(args[1])
+	refresh := fs.Bool("refresh", false, "refresh the version index before running")
+	noRefresh := fs.Bool("no-refresh", false, "use the cached version index, never refresh it")
+	offline := fs.Bool("offline", false, "never touch the network, install from local archives only")
+	var stable, pre *bool
+	var platform, root *string
+	if args[1] == "install" {
+		stable = fs.Bool("stable", false, "install stable version only, refuse beta and rc")
+		pre = fs.Bool("pre", false, "install the latest beta or rc version")
+		platform = fs.String("platform", "", "install the SDK for another platform, eg: linux/arm64")
+		root = fs.String("root", "", "install into this directory instead of SDKDir, with --platform")
+	}
+	var buildName *string
+	var buildPatches []string
+	if args[1] == "build" {
+		buildName = fs.String("name", "", "name of the variant, eg: go1.22.5-acme")
+		fs.Func("patch", "patch file to apply, can be repeated", func(s string) error {
+			buildPatches = append(buildPatches, s)
+			return nil
+		})
+	}
+	var adoptScan, adoptMove, adoptDryRun *bool
+	if args[1] == "adopt" {
+		adoptScan = fs.Bool("scan", false, "find go installations in the usual locations and adopt them")
+		adoptMove = fs.Bool("move", false, "move the SDK into SDKDir instead of using it in place")
+		adoptDryRun = fs.Bool("dry-run", false, "with --scan, list the found go installations only")
+	}
+	var force *bool
+	if args[1] == "remove" || args[1] == "uninstall" || args[1] == "clean" {
+		force = fs.Bool("force", false, "also remove directories not installed by smart-go-dl")
+	}
+	var asJSON *bool
+	if args[1] == "info" || args[1] == "which" || args[1] == "resolve" || args[1] == "doctor" {
+		asJSON = fs.Bool("json", false, "output as json")
+	}
+	var doctorFix *bool
+	if args[1] == "doctor" {
+		doctorFix = fs.Bool("fix", false, "repair the problems which can be repaired automatically")
+	}
+	var listen *string
+	if args[1] == "serve" {
+		listen = fs.String("listen", ":8080", "address to listen on")
+	}
+	var bundleVersions, bundlePlatforms, bundleOutput *string
+	if args[1] == "bundle" {
+		bundleVersions = fs.String("versions", "", "versions to bundle, eg: go1.22,go1.23")
+		bundlePlatforms = fs.String("platforms", "", "platforms to bundle, eg: linux/amd64,linux/arm64")
+		bundleOutput = fs.String("o", "", "output file, eg: bundle.tar")
+	}
+	sub := stringSlice(parseFlags(fs, args[2:]))
+
+	if *offline {
+		internal.SetOffline()
+	}
+	m, err := internal.NewManager()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	internal.Prepare2(m)
+
+	// 只有需要版本列表的命令才会更新版本列表，lock、remove 等本地操作不需要
+	// 安装打包文件时版本号来自其中的 VERSION 文件，不需要版本列表
+	if needIndex[args[1]] && !(args[1] == "install" && sdkmgr.IsArchive(sub.get(0))) || (args[1] == "bundle" && sub.get(0) == "create") {
+		var mode internal.RefreshMode
+		if mode, err = refreshMode(*refresh, *noRefresh); err == nil {
+			err = internal.RefreshIndex(ctx, m, mode)
+		}
+		if err != nil {
+			fatal(ctx, args[1], err)
+		}
+	}
+
 	switch args[1] {
 	case "install":
-		err = internal.Install(ctx, args.get(2))
+		var ch sdkmgr.Channel
+		if ch, err = channel(*stable, *pre); err == nil {
+			err = install(ctx, m, sub.get(0), ch, *platform, *root)
+		}
 	case "clean":
-		err = internal.Clean(ctx, args.get(2))
+		err = m.Clean(ctx, sub.get(0), sdkmgr.RemoveOptions{Force: *force})
 	case "update":
-		err = internal.Update(ctx, args.get(2))
+		err = internal.Update(ctx, m, sub.get(0))
 	case "lock":
-		err = internal.Lock(args.get(2), "add")
+		err = m.Lock(ctx, sub.get(0))
 	case "unlock":
-		err = internal.Lock(args.get(2), "remove")
+		err = m.Unlock(ctx, sub.get(0))
 	case "list":
-		err = internal.List(ctx)
+		err = internal.List(ctx, m)
 	case "remove", "uninstall":
-		err = internal.Remove(ctx, args.get(2))
+		err = m.Remove(ctx, sub.get(0), sdkmgr.RemoveOptions{Force: *force})
 	case "fix":
-		err = internal.Fix(ctx)
+		err = m.LinkLatest(ctx)
+	case "doctor":
+		err = internal.Doctor(ctx, m, *doctorFix, *asJSON)
+	case "check-update":
+		err = internal.CheckUpdate(ctx, m)
+	case "cache":
+		err = internal.Cache(ctx, m, sub.get(0))
+	case "serve":
+		err = internal.Serve(ctx, m, *listen)
+	case "build":
+		// 如 --patch ./patches/*.diff 被 shell 展开后，除第一个外的补丁文件是普通参数
+		err = internal.Build(ctx, m, sub.get(0), *buildName, append(buildPatches, sub[min(1, len(sub)):]...))
+	case "which", "resolve":
+		err = internal.Which(ctx, m, sub.get(0), *asJSON)
+	case "info":
+		err = internal.Info(ctx, m, sub.get(0), *asJSON)
+	case "adopt":
+		err = internal.Adopt(ctx, m, sub, *adoptScan, *adoptMove, *adoptDryRun)
+	case "bundle":
+		switch sub.get(0) {
+		case "create":
+			err = internal.BundleCreate(ctx, m, *bundleOutput, *bundleVersions, *bundlePlatforms)
+		case "import":
+			err = internal.BundleImport(ctx, m, sub.get(1))
+		default:
+			err = fmt.Errorf("unknown bundle action %q, expect create or import", sub.get(0))
+		}
 	default:
 		err = errors.New("not support")
 	}
 
 	if err != nil {
-		log.Fatalf("error: %s failed, %v\n", args[1], err)
+		fatal(ctx, args[1], err)
 	} else {
 		log.Printf("%s success", args[1])
 	}
@@ -132,6 +355,23 @@ func init() {
 	log.SetPrefix("[smart-go-dl] ")
 }
 
+func execGo(ctx context.Context, version string, args []string) (int, error) {
+	m, err := internal.NewManager()
+	if err != nil {
+		return 2, err
+	}
+	return internal.Exec(ctx, m, version, args)
+}
+
+// fatal 输出错误并退出，被信号中断时使用退出码 internal.ExitInterrupted
+func fatal(ctx context.Context, action string, err error) {
+	if ctx.Err() != nil {
+		log.Printf("error: %s interrupted, %v\n", action, err)
+		os.Exit(internal.ExitInterrupted)
+	}
+	log.Fatalf("error: %s failed, %v\n", action, err)
+}
+
 type stringSlice []string
 
 func (s stringSlice) get(index int) string {
@@ -140,3 +380,79 @@ func (s stringSlice) get(index int) string {
 	}
 	return s[index]
 }
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet("smart-go-dl "+name, flag.ExitOnError)
+}
+
+// parseFlags 解析子命令的参数，返回非 flag 的参数
+// 参数和 flag 可以混合使用，如 "install go1.26 --stable"
+func parseFlags(fs *flag.FlagSet, args []string) []string {
+	var result []string
+	for {
+		_ = fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			return result
+		}
+		result = append(result, args[0])
+		args = args[1:]
+	}
+}
+
+// needIndex 需要版本列表的命令
+var needIndex = map[string]bool{
+	"install":      true,
+	"update":       true,
+	"clean":        true,
+	"list":         true,
+	"info":         true,
+	"check-update": true,
+}
+
+// install 安装 SDK，有 --platform 或 --root 参数时，安装到平台名称的目录中，不创建 $GOBIN 下的命令
+// version 也可以是打包文件的路径或者地址，如 ./go1.22.5.linux-amd64.tar.gz
+func install(ctx context.Context, m *sdkmgr.Manager, version string, ch sdkmgr.Channel, platform string, root string) error {
+	var sdk *sdkmgr.SDK
+	var err error
+	if len(platform) > 0 || len(root) > 0 {
+		if m, err = internal.NewPlatformManager(platform, root); err != nil {
+			return err
+		}
+	}
+	if sdkmgr.IsArchive(version) {
+		sdk, err = m.InstallArchive(ctx, version)
+	} else {
+		sdk, err = m.Install(ctx, version, ch)
+	}
+	if err == nil && (len(platform) > 0 || len(root) > 0) {
+		log.Printf("installed %s for %s in %s\n", sdk.Version.Raw, m.Platform(), sdk.GOROOT)
+	}
+	return err
+}
+
+func refreshMode(refresh bool, noRefresh bool) (internal.RefreshMode, error) {
+	switch {
+	case refresh && noRefresh:
+		return internal.RefreshAuto, errors.New("--refresh and --no-refresh cannot be used together")
+	case refresh:
+		return internal.RefreshAlways, nil
+	case noRefresh:
+		return internal.RefreshNever, nil
+	default:
+		return internal.RefreshAuto, nil
+	}
+}
+
+func channel(stable bool, pre bool) (sdkmgr.Channel, error) {
+	switch {
+	case stable && pre:
+		return sdkmgr.ChannelAny, errors.New("--stable and --pre cannot be used together")
+	case stable:
+		return sdkmgr.ChannelStable, nil
+	case pre:
+		return sdkmgr.ChannelPre, nil
+	default:
+		return sdkmgr.ChannelAny, nil
+	}
+}
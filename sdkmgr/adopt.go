@@ -0,0 +1,245 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"debug/buildinfo"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// AdoptOptions Adopt 的参数
+type AdoptOptions struct {
+	// Move 是否将 SDK 移动到 SDKDir 中，否则原地使用，即在 SDKDir 中创建指向它的软链
+	Move bool
+}
+
+// ForeignSDK 不是由 smart-go-dl 安装的 SDK，如 golang.org/dl、/usr/local/go、系统软件包安装的
+type ForeignSDK struct {
+	Version *Version
+
+	// GOROOT SDK 的目录，如 /usr/local/go
+	GOROOT string
+
+	// Managed 是否已经是 smart-go-dl 管理的 SDK，即已经在 SDKDir 中或者已被 Adopt
+	Managed bool
+}
+
+// DetectSDK 识别 dir 目录中 SDK 的版本，依据 VERSION 文件和 bin/go 的编译信息，两者都有时需要一致，
+// 需要是当前平台的 SDK，即包含 pkg/tool/{goos}_{goarch} 目录
+func (m *Manager) DetectSDK(dir string) (*Version, error) {
+	goBin := filepath.Join(dir, "bin", "go"+m.Platform().Exe())
+	if _, err := os.Stat(goBin); err != nil {
+		return nil, err
+	}
+	var binVersion string
+	if bi, err := buildinfo.ReadFile(goBin); err == nil {
+		// 如 golang.org/dl 的 go1.x.y 命令
+		if bi.Path != "cmd/go" {
+			return nil, fmt.Errorf("%s is not the go command, but %s", goBin, bi.Path)
+		}
+		// 如 go1.22.5 X:boringcrypto
+		binVersion, _, _ = strings.Cut(bi.GoVersion, " ")
+	}
+
+	v, err := readVersionFile(dir)
+	switch {
+	case err == nil:
+		if len(binVersion) > 0 && binVersion != v.Raw && binVersion != v.Name() {
+			return nil, fmt.Errorf("VERSION is %s, but %s is %s", v.Raw, goBin, binVersion)
+		}
+	case errors.Is(err, os.ErrNotExist) && len(binVersion) > 0:
+		if v, err = ParseVersion(binVersion); err != nil || v.IsTip() || v.IsLanguage() {
+			return nil, fmt.Errorf("unsupported version %q of %s", binVersion, goBin)
+		}
+	default:
+		return nil, err
+	}
+	p := m.Platform()
+	if _, err = os.Stat(filepath.Join(dir, "pkg", "tool", p.GOOS+"_"+p.GOARCH)); err != nil {
+		return nil, fmt.Errorf("%s is not built for %s", dir, p)
+	}
+	return v, nil
+}
+
+// adoptCandidates 常见的 SDK 安装位置
+func (m *Manager) adoptCandidates() []string {
+	var patterns []string
+	if home, err := os.UserHomeDir(); err == nil {
+		// golang.org/dl 的默认安装目录
+		patterns = append(patterns, filepath.Join(home, "sdk", "go1.*"), filepath.Join(home, "go1.*"))
+	}
+	patterns = append(patterns, filepath.Join(m.opts.SDKDir, "go1.*"))
+	if isWindows() {
+		patterns = append(patterns, `C:\Program Files\Go`, `C:\Go`)
+	} else {
+		patterns = append(patterns,
+			"/usr/local/go", "/usr/local/go1.*", "/opt/go", "/opt/go1.*",
+			"/usr/lib/go", "/usr/lib/go-1.*", "/usr/lib/golang", "/usr/lib64/go/*", "/snap/go/current",
+		)
+	}
+	var dirs []string
+	for _, p := range patterns {
+		ms, _ := filepath.Glob(p)
+		dirs = append(dirs, ms...)
+	}
+	// $PATH 中的 go，如解压到任意目录的打包文件
+	if fp, err := exec.LookPath("go"); err == nil {
+		if fp, err = filepath.EvalSymlinks(fp); err == nil {
+			dirs = append(dirs, filepath.Dir(filepath.Dir(fp)))
+		}
+	}
+	return dirs
+}
+
+// Scan 查找 dirs 中不是由 smart-go-dl 安装的 SDK，dirs 为空时查找常见的安装位置，
+// 如 ~/sdk/go1.x.y（golang.org/dl）、/usr/local/go、/usr/lib/go-1.x、$PATH 中的 go
+func (m *Manager) Scan(ctx context.Context, dirs ...string) ([]*ForeignSDK, error) {
+	if len(dirs) == 0 {
+		dirs = m.adoptCandidates()
+	}
+	seen := make(map[string]bool)
+	var result []*ForeignSDK
+	for _, dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		target, err := filepath.EvalSymlinks(dir)
+		if err != nil || seen[target] {
+			continue
+		}
+		seen[target] = true
+		v, err := m.DetectSDK(target)
+		if err != nil {
+			m.logPrint("scan", dir, "skipped:", err)
+			continue
+		}
+		result = append(result, &ForeignSDK{
+			Version: v,
+			GOROOT:  target,
+			Managed: m.adopted(v, target),
+		})
+	}
+	return result, nil
+}
+
+// adopted dir 是否就是版本 v 的 GOROOT 且有安装信息，或者 GOROOT 是指向它的软链
+func (m *Manager) adopted(v *Version, dir string) bool {
+	target, err := filepath.EvalSymlinks(m.GOROOT(v))
+	return err == nil && target == dir && m.Unpacked(v) && m.checkOwned(m.GOROOT(v)) == nil
+}
+
+// Adopt 接管一个不是由 smart-go-dl 安装的 SDK，如 /usr/local/go，之后和其他已安装的版本一样使用
+//
+// 默认原地使用，即创建软链 {SDKDir}/go1.x.y -> dir，Remove、Clean 时只会删除软链；
+// opts.Move 时会移动到 {SDKDir}/go1.x.y。已在 SDKDir 中、没有安装信息的（如手动放入的），会写入安装信息。
+// 会创建 $GOBIN/go1.x.y 等命令，并将 golang.org/dl 的 go1.x.y 命令替换为 smart-go-dl
+func (m *Manager) Adopt(ctx context.Context, dir string, opts AdoptOptions) (*SDK, error) {
+	if p := m.Platform(); p != CurrentPlatform() {
+		return nil, fmt.Errorf("can only adopt SDKs for the current platform, not %s", p)
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	target, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return nil, err
+	}
+	v, err := m.DetectSDK(target)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := m.lockVersion(m.GOROOT(v))
+	defer unlock()
+	if err = os.MkdirAll(m.opts.SDKDir, 0755); err != nil {
+		return nil, err
+	}
+	gr := m.GOROOT(v)
+	unlockFile, err := lockFile(ctx, filepath.Join(m.opts.SDKDir, "."+filepath.Base(gr)+".lock"))
+	if err != nil {
+		return nil, err
+	}
+	defer unlockFile()
+
+	grReal, err := filepath.EvalSymlinks(gr)
+	switch {
+	case err == nil && grReal == target:
+		// 已经在 SDKDir 中，如 golang.org/dl 安装到 ~/sdk/go1.x.y 的
+		m.logPrint("adopt", target, "is already in", m.opts.SDKDir)
+		if m.checkOwned(gr) != nil {
+			if err = writeManifest(target, &Manifest{Version: v.Raw, Source: "adopt " + target}); err != nil {
+				return nil, err
+			}
+		}
+		if !m.Unpacked(v) {
+			if err = os.WriteFile(filepath.Join(target, unpackedOkay), nil, 0644); err != nil {
+				return nil, err
+			}
+		}
+	case err == nil:
+		return nil, fmt.Errorf("%s is already installed at %s", v.Raw, gr)
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	case opts.Move:
+		m.logPrint("adopt", "move", target, "->", gr)
+		if err = os.Rename(target, gr); err != nil {
+			return nil, fmt.Errorf("move failed, try to adopt it in place: %w", err)
+		}
+		if err = writeManifest(gr, &Manifest{Version: v.Raw, Source: "adopt " + target}); err != nil {
+			return nil, err
+		}
+		if err = os.WriteFile(filepath.Join(gr, unpackedOkay), nil, 0644); err != nil {
+			return nil, err
+		}
+	default:
+		// 原地使用时不修改 dir 中的文件，见 Unpacked，删除时只会删除软链，见 checkOwned
+		m.logPrint("adopt", "link", target, "->", gr)
+		if err = os.Symlink(target, gr); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = m.linkVersion(ctx, v); err != nil {
+		return nil, err
+	}
+	if err = m.replaceDLWrappers(v); err != nil {
+		return nil, err
+	}
+	return m.newSDK(v), m.LinkLatest(ctx)
+}
+
+// replaceDLWrappers 将 $GOBIN、$GOPATH/bin 中 golang.org/dl 的 go1.x.y 命令替换为 smart-go-dl
+func (m *Manager) replaceDLWrappers(v *Version) error {
+	if len(m.opts.Shim) == 0 || len(m.opts.GOBIN) == 0 {
+		return nil
+	}
+	dirs := []string{m.opts.GOBIN}
+	if gp := filepath.SplitList(os.Getenv("GOPATH")); len(gp) > 0 && len(gp[0]) > 0 {
+		dirs = append(dirs, filepath.Join(gp[0], "bin"))
+	} else if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, "go", "bin"))
+	}
+	for _, dir := range dirs {
+		for _, name := range []string{v.Name(), v.Formatted()} {
+			fp := filepath.Join(dir, name+exe())
+			bi, err := buildinfo.ReadFile(fp)
+			if err != nil || bi.Path != "golang.org/dl/"+name {
+				continue
+			}
+			m.logPrint("adopt", "replace golang.org/dl wrapper", fp)
+			if err = m.createLink(m.opts.Shim, fp); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
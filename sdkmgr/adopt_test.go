@@ -0,0 +1,149 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+//go:build !windows
+
+package sdkmgr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+// writeSDK 在 dir 中写入模拟的 SDK
+func writeSDK(t *testing.T, dir string, version string) {
+	t.Helper()
+	for name, content := range sdkFiles("", version, runtime.GOOS, runtime.GOARCH) {
+		fp := filepath.Join(dir, name)
+		fst.NoError(t, os.MkdirAll(filepath.Dir(fp), 0755))
+		fst.NoError(t, os.WriteFile(fp, []byte(content), 0755))
+	}
+}
+
+func TestManager_DetectSDK(t *testing.T) {
+	dir := t.TempDir()
+	m, err := New(Options{SDKDir: filepath.Join(dir, "sdk"), Indexes: []Index{&Dir{Path: dir}}})
+	fst.NoError(t, err)
+
+	t.Run("version file", func(t *testing.T) {
+		gr := filepath.Join(dir, "go")
+		writeSDK(t, gr, "go1.22.5")
+		v, err := m.DetectSDK(gr)
+		fst.NoError(t, err)
+		fst.Equal(t, "go1.22.5", v.Raw)
+	})
+
+	t.Run("not go command", func(t *testing.T) {
+		// 测试程序自身的编译信息不是 cmd/go，如同 golang.org/dl 的 go1.x.y 命令
+		exe, err := os.Executable()
+		fst.NoError(t, err)
+		bf, err := os.ReadFile(exe)
+		fst.NoError(t, err)
+		gr := filepath.Join(dir, "wrapper")
+		writeSDK(t, gr, "go1.22.5")
+		fst.NoError(t, os.WriteFile(filepath.Join(gr, "bin", "go"), bf, 0755))
+		_, err = m.DetectSDK(gr)
+		fst.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := m.DetectSDK(filepath.Join(dir, "not-found"))
+		fst.Error(t, err)
+
+		gr := filepath.Join(dir, "other-platform")
+		for name, content := range sdkFiles("", "go1.21.0", "plan9", "arm") {
+			fp := filepath.Join(gr, name)
+			fst.NoError(t, os.MkdirAll(filepath.Dir(fp), 0755))
+			fst.NoError(t, os.WriteFile(fp, []byte(content), 0755))
+		}
+		_, err = m.DetectSDK(gr)
+		fst.Error(t, err)
+	})
+}
+
+func TestManager_Adopt(t *testing.T) {
+	dir := t.TempDir()
+	shim := filepath.Join(dir, "smart-go-dl")
+	fst.NoError(t, os.WriteFile(shim, nil, 0755))
+	m, err := New(Options{
+		SDKDir:  filepath.Join(dir, "sdk"),
+		GOBIN:   filepath.Join(dir, "bin"),
+		Shim:    shim,
+		Indexes: []Index{&Dir{Path: dir}},
+		Offline: true,
+	})
+	fst.NoError(t, err)
+	fst.NoError(t, os.MkdirAll(m.GOBIN(), 0755))
+	ctx := context.Background()
+
+	inPlace := filepath.Join(dir, "usr", "local", "go")
+	writeSDK(t, inPlace, "go1.22.5")
+	toMove := filepath.Join(dir, "opt", "go1.22.4")
+	writeSDK(t, toMove, "go1.22.4")
+
+	list, err := m.Scan(ctx, inPlace, toMove, filepath.Join(dir, "not-found"))
+	fst.NoError(t, err)
+	fst.Equal(t, 2, len(list))
+	fst.False(t, list[0].Managed)
+
+	sdk, err := m.Adopt(ctx, inPlace, AdoptOptions{})
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.5", sdk.Version.Raw)
+	fst.True(t, m.Unpacked(sdk.Version))
+	// 原地使用，不修改原目录
+	_, err = os.Stat(filepath.Join(inPlace, unpackedOkay))
+	fst.True(t, os.IsNotExist(err))
+	for _, name := range []string{"go1.22.5", "go1.22", "go.latest"} {
+		_, err = os.Lstat(filepath.Join(m.GOBIN(), name))
+		fst.NoError(t, err)
+	}
+	got, err := m.Resolve(ctx, "go1.22")
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.5", got.Version.Raw)
+
+	_, err = m.Adopt(ctx, toMove, AdoptOptions{})
+	fst.NoError(t, err)
+	// 已接管的 SDK 不能重复接管，同版本的其他 SDK 也不能接管
+	_, err = m.Adopt(ctx, inPlace, AdoptOptions{})
+	fst.NoError(t, err)
+	other := filepath.Join(dir, "other", "go")
+	writeSDK(t, other, "go1.22.5")
+	_, err = m.Adopt(ctx, other, AdoptOptions{})
+	fst.Error(t, err)
+
+	list, err = m.Scan(ctx, inPlace, toMove)
+	fst.NoError(t, err)
+	fst.True(t, list[0].Managed)
+	fst.True(t, list[1].Managed)
+
+	// 删除时只删除软链
+	fst.NoError(t, m.Clean(ctx, "go1.22", RemoveOptions{}))
+	fst.False(t, m.Installed(mustParseVersion(t, "go1.22.4")))
+	_, err = os.Stat(filepath.Join(toMove, "VERSION"))
+	fst.NoError(t, err)
+	fst.NoError(t, m.Remove(ctx, "go1.22.5", RemoveOptions{}))
+	fst.False(t, m.Installed(sdk.Version))
+	_, err = os.Stat(filepath.Join(inPlace, "VERSION"))
+	fst.NoError(t, err)
+
+	t.Run("move", func(t *testing.T) {
+		sdk, err := m.Adopt(ctx, other, AdoptOptions{Move: true})
+		fst.NoError(t, err)
+		fst.Equal(t, filepath.Join(m.SDKDir(), "go1.22.5"), sdk.GOROOT)
+		info, err := os.Lstat(sdk.GOROOT)
+		fst.NoError(t, err)
+		fst.True(t, info.IsDir())
+		fst.True(t, m.Unpacked(sdk.Version))
+		mf, err := m.Manifest(sdk.Version)
+		fst.NoError(t, err)
+		fst.Equal(t, "adopt "+other, mf.Source)
+		_, err = os.Stat(other)
+		fst.True(t, os.IsNotExist(err))
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IsArchive 是否打包文件的路径或者地址，如 ./go1.22.5.linux-amd64.tar.gz、https://example.com/go.zip
+func IsArchive(str string) bool {
+	if strings.Contains(str, "://") {
+		return true
+	}
+	return strings.HasSuffix(str, ".tar.gz") || strings.HasSuffix(str, ".zip")
+}
+
+// InstallArchive 安装本地的打包文件，或者 file://、http://、https:// 地址的打包文件，
+// 版本号从其中的 VERSION 文件获取，会按原样解压到 GOROOT，不会修改、缓存
+//
+// 支持 .tar.gz 和 .zip，Go 的根目录可以是打包文件的第一、二层目录，如官方打包文件的 go/、
+// GOPROXY 的 golang.org/toolchain@v0.0.1-go1.22.5.linux-amd64/。
+// 需要是 Options.Platform 平台的 SDK，即包含 pkg/tool/{goos}_{goarch} 目录。
+// 若配置了 GOBIN 和 Shim，还会创建 $GOBIN/go1.x.y，是次要版本已安装的最新版本时，同时创建 $GOBIN/go1.x
+func (m *Manager) InstallArchive(ctx context.Context, src string) (*SDK, error) {
+	if err := os.MkdirAll(m.opts.SDKDir, 0755); err != nil {
+		return nil, err
+	}
+	staging, err := os.MkdirTemp(m.opts.SDKDir, ".archive-*.staging")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+
+	fp, err := m.localArchive(ctx, src, staging)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(fp, ".tar.gz") && !strings.HasSuffix(fp, ".zip") {
+		return nil, fmt.Errorf("%s is not a .tar.gz or .zip file", src)
+	}
+	digest, err := fileSHA256(ctx, fp)
+	if err != nil {
+		return nil, err
+	}
+	m.logPrint("install", fp, "sha256=", digest)
+
+	raw := filepath.Join(staging, "raw")
+	if err = m.unpackArchive(ctx, &Archive{Path: fp}, raw); err != nil {
+		return nil, err
+	}
+	root, err := findGOROOT(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", src, err)
+	}
+	if strings.HasSuffix(fp, ".zip") {
+		if err = fixExecutable(root); err != nil {
+			return nil, err
+		}
+	}
+	v, err := readVersionFile(root)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", src, err)
+	}
+	p := m.Platform()
+	if _, err = os.Stat(filepath.Join(root, "pkg", "tool", p.GOOS+"_"+p.GOARCH)); err != nil {
+		return nil, fmt.Errorf("%s: %s is not built for %s", src, v.Raw, p)
+	}
+
+	unlock := m.lockVersion(m.GOROOT(v))
+	defer unlock()
+	unlockFile, err := lockFile(ctx, filepath.Join(m.opts.SDKDir, "."+filepath.Base(m.GOROOT(v))+".lock"))
+	if err != nil {
+		return nil, err
+	}
+	defer unlockFile()
+
+	if err = m.installGOROOT(root, v, &Manifest{Source: src, SHA256: digest}); err != nil {
+		return nil, err
+	}
+	if err = m.linkVersion(ctx, v); err != nil {
+		return nil, err
+	}
+	return m.newSDK(v), m.LinkLatest(ctx)
+}
+
+// localArchive 返回打包文件的本地路径，http、https 地址会下载到 dir 目录中
+func (m *Manager) localArchive(ctx context.Context, src string, dir string) (string, error) {
+	if !strings.Contains(src, "://") {
+		return src, nil
+	}
+	u, err := url.Parse(src)
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "file":
+		return filepath.FromSlash(u.Path), nil
+	case "http", "https":
+		if m.opts.Offline {
+			return "", fmt.Errorf("download %s: %w", src, ErrOffline)
+		}
+		fp := filepath.Join(dir, path.Base(u.Path))
+		return fp, m.httpGet(ctx, src, fp)
+	default:
+		return "", fmt.Errorf("unsupported url %q", src)
+	}
+}
+
+// findGOROOT 查找解压后的 Go 根目录，即 VERSION 文件所在的目录，最多查找两层子目录
+func findGOROOT(dir string) (string, error) {
+	for _, pattern := range []string{"VERSION", "*/VERSION", "*/*/VERSION"} {
+		ms, _ := filepath.Glob(filepath.Join(dir, pattern))
+		if len(ms) == 1 {
+			return filepath.Dir(ms[0]), nil
+		}
+		if len(ms) > 1 {
+			return "", fmt.Errorf("more than one VERSION file found: %q", ms)
+		}
+	}
+	return "", fmt.Errorf("no VERSION file found")
+}
+
+// readVersionFile 读取 GOROOT/VERSION 第一行的版本号，如 go1.22.5
+func readVersionFile(root string) (*Version, error) {
+	bf, err := os.ReadFile(filepath.Join(root, "VERSION"))
+	if err != nil {
+		return nil, err
+	}
+	line, _, _ := strings.Cut(string(bf), "\n")
+	v, err := ParseVersion(strings.TrimSpace(line))
+	if err != nil || v.IsTip() || v.IsLanguage() {
+		return nil, fmt.Errorf("unsupported version %q in VERSION file", line)
+	}
+	return v, nil
+}
@@ -0,0 +1,139 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+// writeArchive 将 files 写入 .tar.gz 或者 .zip 文件
+func writeArchive(t *testing.T, fp string, files map[string]string) {
+	t.Helper()
+	bf := &bytes.Buffer{}
+	if strings.HasSuffix(fp, ".zip") {
+		zw := zip.NewWriter(bf)
+		for name, content := range files {
+			w, err := zw.Create(name)
+			fst.NoError(t, err)
+			_, err = w.Write([]byte(content))
+			fst.NoError(t, err)
+		}
+		fst.NoError(t, zw.Close())
+	} else {
+		gw := gzip.NewWriter(bf)
+		tw := tar.NewWriter(gw)
+		for name, content := range files {
+			fst.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content))}))
+			_, err := tw.Write([]byte(content))
+			fst.NoError(t, err)
+		}
+		fst.NoError(t, tw.Close())
+		fst.NoError(t, gw.Close())
+	}
+	fst.NoError(t, os.WriteFile(fp, bf.Bytes(), 0644))
+}
+
+// sdkFiles 模拟的 SDK 中的文件，root 为 Go 根目录在打包文件中的路径
+func sdkFiles(root string, version string, goos string, goarch string) map[string]string {
+	return map[string]string{
+		root + "VERSION": version + "\ntime 2024-07-02T20:00:00Z\n",
+		root + "bin/go" + CurrentPlatform().Exe():             version,
+		root + "pkg/tool/" + goos + "_" + goarch + "/compile": "",
+	}
+}
+
+func TestManager_InstallArchive(t *testing.T) {
+	dir := t.TempDir()
+	shim := filepath.Join(dir, "smart-go-dl")
+	fst.NoError(t, os.WriteFile(shim, nil, 0755))
+	m, err := New(Options{
+		SDKDir:  filepath.Join(dir, "sdk"),
+		GOBIN:   filepath.Join(dir, "bin"),
+		Shim:    shim,
+		Indexes: []Index{&Dir{Path: dir}},
+		Offline: true,
+	})
+	fst.NoError(t, err)
+	fst.NoError(t, os.MkdirAll(m.GOBIN(), 0755))
+	ctx := context.Background()
+	goos, goarch := runtime.GOOS, runtime.GOARCH
+
+	t.Run("tar.gz", func(t *testing.T) {
+		fp := filepath.Join(dir, "vetted.tar.gz")
+		writeArchive(t, fp, sdkFiles("go/", "go1.22.5", goos, goarch))
+		sdk, err := m.InstallArchive(ctx, fp)
+		fst.NoError(t, err)
+		fst.Equal(t, "go1.22.5", sdk.Version.Raw)
+		fst.Equal(t, filepath.Join(m.SDKDir(), "go1.22.5"), sdk.GOROOT)
+		fst.True(t, m.Unpacked(sdk.Version))
+		for _, name := range []string{"go1.22.5", "go1.22", "go.latest"} {
+			_, err = os.Lstat(filepath.Join(m.GOBIN(), name+CurrentPlatform().Exe()))
+			fst.NoError(t, err)
+		}
+	})
+
+	t.Run("file url of module zip", func(t *testing.T) {
+		fp := filepath.Join(dir, "toolchain.zip")
+		writeArchive(t, fp, sdkFiles("golang.org/toolchain@v0.0.1-go1.22.4."+goos+"-"+goarch+"/", "go1.22.4", goos, goarch))
+		sdk, err := m.InstallArchive(ctx, (&url.URL{Scheme: "file", Path: filepath.ToSlash(fp)}).String())
+		fst.NoError(t, err)
+		fst.Equal(t, "go1.22.4", sdk.Version.Raw)
+		fst.True(t, m.Installed(sdk.Version))
+		// go1.22.5 已安装，go1.22 依然链接到 go1.22.5
+		link, err := os.Readlink(filepath.Join(m.GOBIN(), "go1.22"))
+		if err == nil {
+			fst.Equal(t, "go1.22.5", link)
+		}
+	})
+
+	t.Run("https url", func(t *testing.T) {
+		fp := filepath.Join(dir, "go1.23.1.tar.gz")
+		writeArchive(t, fp, sdkFiles("go/", "go1.23.1", goos, goarch))
+		ts := httptest.NewServer(http.FileServer(http.Dir(dir)))
+		defer ts.Close()
+		online, err := New(Options{SDKDir: m.SDKDir(), Indexes: []Index{&Dir{Path: dir}}})
+		fst.NoError(t, err)
+		sdk, err := online.InstallArchive(ctx, ts.URL+"/go1.23.1.tar.gz")
+		fst.NoError(t, err)
+		fst.Equal(t, "go1.23.1", sdk.Version.Raw)
+
+		_, err = m.InstallArchive(ctx, ts.URL+"/go1.23.1.tar.gz")
+		fst.ErrorIs(t, err, ErrOffline)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		files := map[string]map[string]string{
+			"other-platform.tar.gz": sdkFiles("go/", "go1.21.0", "plan9", "arm"),
+			"no-version.tar.gz":     {"go/bin/go": ""},
+			"devel.tar.gz":          sdkFiles("go/", "devel go1.24-abcdef", goos, goarch),
+		}
+		for name, content := range files {
+			fp := filepath.Join(dir, name)
+			writeArchive(t, fp, content)
+			_, err := m.InstallArchive(ctx, fp)
+			fst.Error(t, err)
+		}
+		fst.False(t, m.Installed(mustParseVersion(t, "go1.21.0")))
+		_, err := m.InstallArchive(ctx, filepath.Join(dir, "not-found.tar.gz"))
+		fst.Error(t, err)
+
+		ms, _ := filepath.Glob(filepath.Join(m.SDKDir(), ".archive-*"))
+		fst.Empty(t, ms)
+	})
+}
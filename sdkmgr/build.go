@@ -0,0 +1,184 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MinBootstrap 从源码构建该版本需要的最低引导版本，即 GOROOT_BOOTSTRAP 的最低版本
+//
+//	go1.5 - go1.19  : go1.4
+//	go1.20 - go1.21 : go1.17.13
+//	go1.22 及之后    : 前两个偶数次要版本的 .6 修订版本，如 go1.24 为 go1.22.6，go1.26 为 go1.24.6
+func MinBootstrap(v *Version) (*Version, error) {
+	if v.IsTip() {
+		return nil, errors.New("gotip's bootstrap version depends on its source")
+	}
+	return minBootstrapOf(v.Minor)
+}
+
+func minBootstrapOf(minor int) (*Version, error) {
+	var name string
+	switch {
+	case minor < 5:
+		return nil, fmt.Errorf("go1.%d can not be built by make.bash", minor)
+	case minor < 20:
+		name = "go1.4"
+	case minor < 22:
+		name = "go1.17.13"
+	default:
+		name = fmt.Sprintf("go1.%d.6", minor-2-minor%2)
+	}
+	return ParseVersion(name)
+}
+
+var (
+	minBootstrapReg = regexp.MustCompile(`minBootstrap\w*\s*=\s*"(go[0-9.]+)"`)
+	goVersionReg    = regexp.MustCompile(`const\s+Version\s*=\s*(\d+)`)
+)
+
+// sourceMinBootstrap 从源码中读取需要的最低引导版本，用于 gotip
+// 优先使用 src/cmd/dist/buildtool.go 中的 minBootstrapVersion，
+// 其次依据 src/internal/goversion/goversion.go 中的次要版本号计算
+func sourceMinBootstrap(goroot string) (*Version, error) {
+	bf, err := os.ReadFile(filepath.Join(goroot, "src", "cmd", "dist", "buildtool.go"))
+	if err == nil {
+		if ms := minBootstrapReg.FindSubmatch(bf); len(ms) == 2 {
+			return ParseVersion(string(ms[1]))
+		}
+	}
+	bf, err = os.ReadFile(filepath.Join(goroot, "src", "internal", "goversion", "goversion.go"))
+	if err != nil {
+		return nil, err
+	}
+	ms := goVersionReg.FindSubmatch(bf)
+	if len(ms) != 2 {
+		return nil, errors.New("can not find the go version of the source")
+	}
+	minor, err := strconv.Atoi(string(ms[1]))
+	if err != nil {
+		return nil, err
+	}
+	return minBootstrapOf(minor)
+}
+
+// findBootstrap 选择引导版本的 GOROOT
+// 优先使用环境变量 GOROOT_BOOTSTRAP，否则使用已安装的、不低于 min 的最新正式版本，不会使用变体版本
+func (m *Manager) findBootstrap(ctx context.Context, minVer *Version) (string, error) {
+	if root := os.Getenv("GOROOT_BOOTSTRAP"); len(root) > 0 {
+		m.logPrint("bootstrap", "GOROOT_BOOTSTRAP=", root)
+		return root, nil
+	}
+	sdks, err := m.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	// sdks 是按照版本倒序排列的
+	for _, s := range sdks {
+		if s.Version.IsTip() || s.Version.IsVariant() || !s.Version.IsNormal() || !m.Unpacked(s.Version) {
+			continue
+		}
+		if s.Version.Compare(minVer) >= 0 {
+			m.logPrint("bootstrap", "using", s.GOROOT)
+			return s.GOROOT, nil
+		}
+	}
+	return "", fmt.Errorf("no installed go >= %s to bootstrap, install one first, eg: 'smart-go-dl install %s'", minVer.Raw, minVer.Normalized)
+}
+
+// buildFromSource 获取源码打包文件解压到 root，并使用满足要求的引导版本构建
+// 会先查找引导版本，没有时不会下载源码
+func (m *Manager) buildFromSource(ctx context.Context, v *Version, dlDir string, root string, mf *Manifest) error {
+	minVer, err := MinBootstrap(v)
+	if err != nil {
+		return err
+	}
+	bootstrap, err := m.findBootstrap(ctx, minVer)
+	if err != nil {
+		return err
+	}
+	p := m.Platform()
+	req := &FetchRequest{
+		Version: v,
+		GOOS:    p.GOOS,
+		GOARCH:  p.GOARCH,
+		Source:  true,
+		Dir:     dlDir,
+	}
+	err = m.fetch(ctx, req, func(ar *Archive) error {
+		return m.unpackTo(ctx, ar, root, mf)
+	})
+	if err != nil {
+		return err
+	}
+	mf.Source = "build from source by " + mf.Source
+	m.logPrint("build", v.Name(), "from source for", p.String())
+	return m.makeBash(ctx, root, bootstrap)
+}
+
+// makeBash 在 goroot 中执行 src/make.bash（windows 下为 make.bat）构建 Go
+func (m *Manager) makeBash(ctx context.Context, goroot string, bootstrap string) error {
+	script := "make.bash"
+	if isWindows() {
+		script = "make.bat"
+	}
+	cmd := exec.CommandContext(ctx, filepath.Join(goroot, "src", script))
+	cmd.Dir = filepath.Join(goroot, "src")
+	setCancel(cmd)
+	cmd.Env = buildEnv(bootstrap)
+	cmd.Stdout = m.output
+	cmd.Stderr = m.output
+	m.logPrint("exec", cmd.String(), "GOROOT_BOOTSTRAP=", bootstrap)
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("%s failed: %w", script, err)
+	}
+	return nil
+}
+
+// buildEnv make.bash 的环境变量，去掉会影响构建结果的变量
+func buildEnv(bootstrap string) []string {
+	var env []string
+	for _, kv := range os.Environ() {
+		k, _, _ := strings.Cut(kv, "=")
+		switch k {
+		case "GOROOT", "GOBIN", "GOOS", "GOARCH", "GOFLAGS", "GOTOOLCHAIN", "GOROOT_BOOTSTRAP", "GOEXPERIMENT":
+			continue
+		}
+		env = append(env, kv)
+	}
+	return append(env, "GOROOT_BOOTSTRAP="+bootstrap, "GOTOOLCHAIN=local")
+}
+
+// git 执行 git 命令，返回标准输出的内容
+func (m *Manager) git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	setCancel(cmd)
+	if m.opts.InsecureSkipVerify {
+		cmd.Env = append(os.Environ(), "GIT_SSL_NO_VERIFY=true")
+	}
+	m.logPrint("exec", cmd.String())
+	out := &bytes.Buffer{}
+	cmd.Stdout = out
+	cmd.Stderr = m.output
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	return strings.TrimSpace(out.String()), err
+}
@@ -0,0 +1,177 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+// fakeMakeBash 模拟 make.bash：检查 GOROOT_BOOTSTRAP，生成输出 VERSION 的 bin/go，并记录构建次数
+const fakeMakeBash = `#!/bin/sh
+set -e
+test -x "$GOROOT_BOOTSTRAP/bin/go"
+test "$GOTOOLCHAIN" = local
+echo built >> "$BUILD_LOG"
+mkdir -p ../bin
+printf '#!/bin/sh\ncat "$(dirname "$0")/../VERSION"\n' > ../bin/go
+chmod +x ../bin/go
+`
+
+func TestMinBootstrap(t *testing.T) {
+	tests := map[string]string{
+		"go1.5":     "go1.4",
+		"go1.19.13": "go1.4",
+		"go1.20":    "go1.17.13",
+		"go1.21.5":  "go1.17.13",
+		"go1.22.5":  "go1.20.6",
+		"go1.23rc1": "go1.20.6",
+		"go1.24.1":  "go1.22.6",
+		"go1.25.0":  "go1.22.6",
+		"go1.26.2":  "go1.24.6",
+		"go1.27rc1": "go1.24.6",
+	}
+	for version, want := range tests {
+		t.Run(version, func(t *testing.T) {
+			got, err := MinBootstrap(mustParseVersion(t, version))
+			fst.NoError(t, err)
+			fst.Equal(t, want, got.Raw)
+		})
+	}
+	_, err := MinBootstrap(mustParseVersion(t, "gotip"))
+	fst.Error(t, err)
+}
+
+// sourceArchiveOf 模拟官方源码打包文件，包含 VERSION 和 src/make.bash
+func sourceArchiveOf(t *testing.T, v *Version) []byte {
+	t.Helper()
+	bf := &bytes.Buffer{}
+	gw := gzip.NewWriter(bf)
+	tw := tar.NewWriter(gw)
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"go/VERSION", v.Name()},
+		{"go/src/make.bash", fakeMakeBash},
+	}
+	for _, f := range files {
+		fst.NoError(t, tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0755, Size: int64(len(f.content))}))
+		_, err := tw.Write([]byte(f.content))
+		fst.NoError(t, err)
+	}
+	fst.NoError(t, tw.Close())
+	fst.NoError(t, gw.Close())
+	return bf.Bytes()
+}
+
+func TestManager_Download_source(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("make.bat is not supported by the test")
+	}
+	t.Setenv("BUILD_LOG", filepath.Join(t.TempDir(), "build.log"))
+	t.Setenv("GOROOT_BOOTSTRAP", "")
+	v := mustParseVersion(t, "go1.22.5")
+
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+v.SourceArchiveName() {
+			http.NotFound(w, r)
+			return
+		}
+		hits++
+		_, _ = w.Write(sourceArchiveOf(t, v))
+	}))
+	defer ts.Close()
+
+	newManager := func(t *testing.T) *Manager {
+		m, err := New(Options{
+			SDKDir:    t.TempDir(),
+			CacheSize: -1,
+			Mirrors:   []string{ts.URL},
+		})
+		fst.NoError(t, err)
+		return m
+	}
+	ctx := context.Background()
+
+	t.Run("no bootstrap", func(t *testing.T) {
+		m := newManager(t)
+		err := m.Download(ctx, v)
+		fst.Error(t, err)
+		fst.True(t, strings.Contains(err.Error(), "no installed go >= go1.20.6"))
+		fst.Equal(t, 0, hits)
+		fst.False(t, m.Installed(v))
+	})
+
+	t.Run("build", func(t *testing.T) {
+		m := newManager(t)
+		// 不满足要求的 go1.20.5 不会被使用
+		for _, name := range []string{"go1.20.5", "go1.21.0"} {
+			gr := filepath.Join(m.opts.SDKDir, name)
+			fst.NoError(t, os.MkdirAll(filepath.Join(gr, "bin"), 0755))
+			fst.NoError(t, os.WriteFile(filepath.Join(gr, "bin", "go"), nil, 0755))
+			fst.NoError(t, os.WriteFile(filepath.Join(gr, unpackedOkay), nil, 0644))
+		}
+		fst.NoError(t, m.Download(ctx, v))
+		fst.Equal(t, 1, hits)
+		fst.True(t, m.Installed(v))
+		out, err := exec.Command(filepath.Join(m.GOROOT(v), "bin", "go")).Output()
+		fst.NoError(t, err)
+		fst.Equal(t, "go1.22.5", string(out))
+	})
+}
+
+func TestManager_Download_fetchFailed(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("make.bat is not supported by the test")
+	}
+	buildLog := filepath.Join(t.TempDir(), "build.log")
+	t.Setenv("BUILD_LOG", buildLog)
+	t.Setenv("GOROOT_BOOTSTRAP", "")
+	v := mustParseVersion(t, "go1.22.5")
+
+	// 网络错误等不是不存在的错误，不会使用源码构建
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/"+v.SourceArchiveName() {
+			hits++
+			_, _ = w.Write(sourceArchiveOf(t, v))
+			return
+		}
+		http.Error(w, "try again later", http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	m, err := New(Options{
+		SDKDir:    t.TempDir(),
+		CacheSize: -1,
+		Mirrors:   []string{ts.URL},
+	})
+	fst.NoError(t, err)
+	gr := filepath.Join(m.opts.SDKDir, "go1.21.0")
+	fst.NoError(t, os.MkdirAll(filepath.Join(gr, "bin"), 0755))
+	fst.NoError(t, os.WriteFile(filepath.Join(gr, "bin", "go"), nil, 0755))
+	fst.NoError(t, os.WriteFile(filepath.Join(gr, unpackedOkay), nil, 0644))
+
+	err = m.Download(context.Background(), v)
+	fst.ErrorContains(t, err, "503")
+	fst.Equal(t, 0, hits)
+	_, err = os.Stat(buildLog)
+	fst.True(t, os.IsNotExist(err))
+	fst.False(t, m.Installed(v))
+}
@@ -0,0 +1,407 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// bundleManifestName 打包文件中的清单文件
+	bundleManifestName = "bundle.json"
+
+	// bundleSumsName 打包文件中的校验文件，格式和 sha256sum 命令的输出一致，方便人工审核
+	bundleSumsName = "SHA256SUMS"
+
+	// bundleIndexName 导入后 BundleDir 中的版本列表快照，多次导入时会合并
+	bundleIndexName = "index.txt"
+)
+
+// BundleOptions 创建离线包的参数
+type BundleOptions struct {
+	// Versions 版本号或者版本约束，如 go1.22、go1.23.4、~1.22
+	Versions []string
+
+	// Platforms 目标平台，为空时为当前平台
+	Platforms []Platform
+}
+
+// BundleManifest 离线包的清单
+type BundleManifest struct {
+	Created time.Time `json:"created"`
+
+	// Versions 离线包中包含的版本，如 go1.22.5
+	Versions []string `json:"versions"`
+
+	Platforms []string `json:"platforms"`
+
+	// Index 创建时的版本列表快照，导入后作为版本列表的一部分
+	Index []string `json:"index"`
+
+	Files []BundleFile `json:"files"`
+}
+
+// BundleFile 离线包中的 SDK 打包文件
+type BundleFile struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// BundleDir 导入的离线包的存放目录，其中的打包文件在离线模式下也可以用于安装
+func (m *Manager) BundleDir() string {
+	return filepath.Join(m.opts.DataDir, "bundle")
+}
+
+// CreateBundle 创建离线包，以 tar 格式写入 w
+//
+// 离线包中包含各版本、各平台的官方打包文件，它们的 SHA256SUMS 校验文件，
+// 以及当前版本列表的快照，可以拷贝到不能联网的机器上，使用 ImportBundle 导入后安装
+func (m *Manager) CreateBundle(ctx context.Context, w io.Writer, opts BundleOptions) (*BundleManifest, error) {
+	if len(opts.Versions) == 0 {
+		return nil, errors.New("no versions to bundle")
+	}
+	var platforms []Platform
+	for _, p := range opts.Platforms {
+		if !slices.Contains(platforms, p) {
+			platforms = append(platforms, p)
+		}
+	}
+	if len(platforms) == 0 {
+		platforms = []Platform{CurrentPlatform()}
+	}
+	all, err := m.Versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &BundleManifest{
+		Created: time.Now().UTC().Truncate(time.Second),
+	}
+	for _, v := range all.All() {
+		if !v.IsTip() {
+			manifest.Index = append(manifest.Index, v.Raw)
+		}
+	}
+	for _, p := range platforms {
+		manifest.Platforms = append(manifest.Platforms, p.String())
+	}
+
+	var versions []*Version
+	for _, str := range opts.Versions {
+		v, err := m.ResolveRelease(ctx, str)
+		if err != nil {
+			return nil, err
+		}
+		if v.IsTip() {
+			return nil, errors.New("gotip can not be bundled")
+		}
+		if !slices.Contains(manifest.Versions, v.Raw) {
+			versions = append(versions, v)
+			manifest.Versions = append(manifest.Versions, v.Raw)
+		}
+	}
+
+	if err = os.MkdirAll(m.opts.DataDir, 0755); err != nil {
+		return nil, err
+	}
+	dlDir, err := os.MkdirTemp(m.opts.DataDir, ".bundle-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dlDir)
+
+	tw := tar.NewWriter(w)
+	for _, v := range versions {
+		for _, p := range platforms {
+			req := &FetchRequest{
+				Version: v,
+				GOOS:    p.GOOS,
+				GOARCH:  p.GOARCH,
+				Dir:     dlDir,
+			}
+			name := req.ArchiveName()
+			m.logPrint("bundle", "add", name)
+			// 先复制到临时文件，获取成功后才写入 tar，避免某个来源失败时在 tar 中留下不完整的文件
+			staged := filepath.Join(dlDir, name+".staged")
+			err = m.fetch(ctx, req, func(ar *Archive) error {
+				// 只打包官方格式的打包文件，导入后才可以按照文件名找到
+				if ar.StripComponents != 1 || filepath.Base(ar.Path) != name {
+					return fmt.Errorf("%s is not an official archive", ar.Path)
+				}
+				return copyFile(ctx, ar.Path, staged)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("bundle %s failed: %w", name, err)
+			}
+			bf, err := writeTarFile(tw, name, staged)
+			if err != nil {
+				return nil, err
+			}
+			manifest.Files = append(manifest.Files, *bf)
+			_ = os.Remove(staged)
+		}
+	}
+
+	var sums strings.Builder
+	for _, f := range manifest.Files {
+		fmt.Fprintf(&sums, "%s  %s\n", f.SHA256, f.Name)
+	}
+	if err = writeTarBytes(tw, bundleSumsName, []byte(sums.String())); err != nil {
+		return nil, err
+	}
+	bf, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = writeTarBytes(tw, bundleManifestName, bf); err != nil {
+		return nil, err
+	}
+	return manifest, tw.Close()
+}
+
+// copyFile 复制文件 from 到 to
+func copyFile(ctx context.Context, from string, to string) error {
+	f, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = copyWithSum(ctx, f, to)
+	return err
+}
+
+func writeTarFile(tw *tar.Writer, name string, path string) (*BundleFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	hd := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}
+	if err = tw.WriteHeader(hd); err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(tw, h), f); err != nil {
+		return nil, err
+	}
+	return &BundleFile{
+		Name:   name,
+		SHA256: hex.EncodeToString(h.Sum(nil)),
+		Size:   info.Size(),
+	}, nil
+}
+
+func writeTarBytes(tw *tar.Writer, name string, content []byte) error {
+	hd := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(hd); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
+
+// ImportBundle 导入 CreateBundle 创建的离线包，不会访问网络
+//
+// 所有打包文件的 SHA256 都和清单、SHA256SUMS 一致时，才会将其放入 BundleDir，
+// 并将版本列表快照合并到版本列表中，之后可以使用 Install 安装离线包中的版本
+func (m *Manager) ImportBundle(ctx context.Context, path string) (*BundleManifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dir := m.BundleDir()
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	unlock, err := lockFile(ctx, filepath.Join(dir, ".lock"))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	staging, err := os.MkdirTemp(dir, ".import-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+
+	var manifest *BundleManifest
+	var sumsFile map[string]string
+	sums := make(map[string]string)
+	tr := tar.NewReader(f)
+	for {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		hd, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundle %s: %w", path, err)
+		}
+		name := hd.Name
+		if hd.Typeflag != tar.TypeReg || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+			return nil, fmt.Errorf("invalid bundle %s: unexpected entry %q", path, name)
+		}
+		if name == bundleManifestName {
+			manifest = &BundleManifest{}
+			if err = json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", bundleManifestName, err)
+			}
+			continue
+		}
+		if name == bundleSumsName {
+			if sumsFile, err = parseSums(tr); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", bundleSumsName, err)
+			}
+			continue
+		}
+		if sums[name], err = copyWithSum(ctx, tr, filepath.Join(staging, name)); err != nil {
+			return nil, err
+		}
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("invalid bundle %s: %s not found", path, bundleManifestName)
+	}
+	if sumsFile == nil {
+		return nil, fmt.Errorf("invalid bundle %s: %s not found", path, bundleSumsName)
+	}
+
+	// 打包文件需要和清单、SHA256SUMS 都一致，SHA256SUMS 可能被人工审核过
+	for _, bf := range manifest.Files {
+		got, ok := sums[bf.Name]
+		if !ok {
+			return nil, fmt.Errorf("invalid bundle %s: %s not found", path, bf.Name)
+		}
+		if got != bf.SHA256 {
+			return nil, fmt.Errorf("checksum mismatch for %s: got %s, want %s", bf.Name, got, bf.SHA256)
+		}
+		if want := sumsFile[bf.Name]; got != want {
+			return nil, fmt.Errorf("checksum mismatch for %s: got %s, want %s in %s", bf.Name, got, want, bundleSumsName)
+		}
+		delete(sums, bf.Name)
+		delete(sumsFile, bf.Name)
+	}
+	for name := range sums {
+		return nil, fmt.Errorf("invalid bundle %s: %s not in %s", path, name, bundleManifestName)
+	}
+	for name := range sumsFile {
+		return nil, fmt.Errorf("invalid bundle %s: %s in %s not found", path, name, bundleSumsName)
+	}
+
+	for _, bf := range manifest.Files {
+		m.logPrint("bundle", "import", bf.Name)
+		if err = os.Rename(filepath.Join(staging, bf.Name), filepath.Join(dir, bf.Name)); err != nil {
+			return nil, err
+		}
+	}
+	index, _ := m.bundleIndex()
+	for _, v := range manifest.Index {
+		if !slices.Contains(index, v) {
+			index = append(index, v)
+		}
+	}
+	content := strings.Join(index, "\n") + "\n"
+	if err = os.WriteFile(filepath.Join(dir, bundleIndexName), []byte(content), 0644); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func copyWithSum(ctx context.Context, r io.Reader, to string) (string, error) {
+	f, err := os.Create(to)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	buf := make([]byte, 1<<20)
+	for {
+		if err = ctx.Err(); err != nil {
+			return "", err
+		}
+		n, err := r.Read(buf)
+		if n > 0 {
+			h.Write(buf[:n])
+			if _, err := f.Write(buf[:n]); err != nil {
+				return "", err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), f.Close()
+}
+
+// parseSums 解析 sha256sum 命令格式的校验文件，返回文件名和 SHA256 的对应关系
+func parseSums(r io.Reader) (map[string]string, error) {
+	sums := make(map[string]string)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if len(line) == 0 {
+			continue
+		}
+		sum, name, ok := strings.Cut(line, " ")
+		// 二进制模式时文件名前有 *
+		name = strings.TrimPrefix(strings.TrimSpace(name), "*")
+		if !ok || len(name) == 0 {
+			return nil, fmt.Errorf("invalid line %q", line)
+		}
+		sums[name] = sum
+	}
+	return sums, sc.Err()
+}
+
+// bundleIndex 已导入的离线包中的版本列表快照
+func (m *Manager) bundleIndex() ([]string, error) {
+	f, err := os.Open(filepath.Join(m.BundleDir(), bundleIndexName))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var vs []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); len(line) > 0 {
+			vs = append(vs, line)
+		}
+	}
+	return vs, sc.Err()
+}
@@ -0,0 +1,190 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestManager_Bundle(t *testing.T) {
+	ctx := context.Background()
+	src := t.TempDir()
+	other := Platform{GOOS: "linux", GOARCH: "arm64"}
+	for _, version := range []string{"go1.21.9", "go1.22.4", "go1.22.5"} {
+		v := mustParseVersion(t, version)
+		for _, p := range []Platform{CurrentPlatform(), other} {
+			name := v.ArchiveName(p.GOOS, p.GOARCH)
+			fst.NoError(t, os.WriteFile(filepath.Join(src, name), archiveOf(t, name), 0644))
+		}
+	}
+	online, err := New(Options{
+		SDKDir:   t.TempDir(),
+		Indexes:  []Index{&Dir{Path: src}},
+		Fetchers: []Fetcher{&Dir{Path: src}},
+	})
+	fst.NoError(t, err)
+
+	bf := &bytes.Buffer{}
+	manifest, err := online.CreateBundle(ctx, bf, BundleOptions{
+		Versions:  []string{"go1.22", "go1.22.5"},
+		Platforms: []Platform{CurrentPlatform(), other},
+	})
+	fst.NoError(t, err)
+	fst.Equal(t, []string{"go1.22.5"}, manifest.Versions)
+	fst.Equal(t, []string{"go1.22.5", "go1.22.4", "go1.21.9"}, manifest.Index)
+
+	_, err = online.CreateBundle(ctx, &bytes.Buffer{}, BundleOptions{Versions: []string{"go1.23"}})
+	fst.Error(t, err)
+
+	// 不能联网的机器
+	offline, err := New(Options{
+		SDKDir:  t.TempDir(),
+		Offline: true,
+		Indexes: []Index{&FeedIndex{URL: "http://127.0.0.1:1/"}},
+	})
+	fst.NoError(t, err)
+	fp := filepath.Join(t.TempDir(), "bundle.tar")
+	fst.NoError(t, os.WriteFile(fp, bf.Bytes(), 0644))
+	_, err = offline.ImportBundle(ctx, fp)
+	fst.NoError(t, err)
+
+	vs, err := offline.Versions(ctx)
+	fst.NoError(t, err)
+	fst.Equal(t, 3, len(vs.All())-1)
+
+	sdk, err := offline.Install(ctx, "go1.22", ChannelAny)
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.5", sdk.Version.Raw)
+	fst.True(t, offline.Installed(sdk.Version))
+
+	// 版本列表中有，但是离线包中没有
+	_, err = offline.Install(ctx, "go1.21", ChannelAny)
+	fst.ErrorIs(t, err, ErrOffline)
+}
+
+// brokenFetcher 返回不能读取的打包文件（目录），模拟下载到一半失败
+type brokenFetcher struct {
+	dir string
+}
+
+func (b *brokenFetcher) Name() string {
+	return "broken"
+}
+
+func (b *brokenFetcher) Fetch(ctx context.Context, req *FetchRequest) (*Archive, error) {
+	fp := filepath.Join(b.dir, req.ArchiveName())
+	if err := os.MkdirAll(fp, 0755); err != nil {
+		return nil, err
+	}
+	return &Archive{Path: fp, StripComponents: 1}, nil
+}
+
+func TestManager_CreateBundle_fetchFailed(t *testing.T) {
+	ctx := context.Background()
+	src := t.TempDir()
+	v := mustParseVersion(t, "go1.22.5")
+	name := v.ArchiveName(runtime.GOOS, runtime.GOARCH)
+	fst.NoError(t, os.WriteFile(filepath.Join(src, name), archiveOf(t, name), 0644))
+	m, err := New(Options{
+		SDKDir:   t.TempDir(),
+		Indexes:  []Index{&Dir{Path: src}},
+		Fetchers: []Fetcher{&brokenFetcher{dir: t.TempDir()}, &Dir{Path: src}},
+	})
+	fst.NoError(t, err)
+
+	bf := &bytes.Buffer{}
+	_, err = m.CreateBundle(ctx, bf, BundleOptions{Versions: []string{"go1.22.5"}})
+	fst.NoError(t, err)
+
+	// 失败的来源不会在 tar 中留下文件
+	var names []string
+	tr := tar.NewReader(bf)
+	for {
+		hd, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		fst.NoError(t, err)
+		names = append(names, hd.Name)
+	}
+	fst.Equal(t, []string{name, bundleSumsName, bundleManifestName}, names)
+}
+
+func TestManager_ImportBundle_invalid(t *testing.T) {
+	m, err := New(Options{SDKDir: t.TempDir()})
+	fst.NoError(t, err)
+	name := mustParseVersion(t, "go1.22.5").ArchiveName("linux", "amd64")
+	content := archiveOf(t, name)
+	sum := sha256.Sum256(content)
+	good := hex.EncodeToString(sum[:])
+	manifestOf := func(sha string) []byte {
+		return []byte(`{"versions":["go1.22.5"],"index":["go1.22.5"],"files":[{"name":"` + name + `","sha256":"` + sha + `"}]}`)
+	}
+	manifest := manifestOf(good)
+	sums := []byte(good + "  " + name + "\n")
+
+	tests := []struct {
+		name  string
+		files map[string][]byte
+	}{
+		{
+			name:  "checksum mismatch",
+			files: map[string][]byte{name: content, bundleManifestName: manifestOf("bad"), bundleSumsName: sums},
+		},
+		{
+			name:  "SHA256SUMS mismatch",
+			files: map[string][]byte{name: content, bundleManifestName: manifest, bundleSumsName: []byte("bad  " + name + "\n")},
+		},
+		{
+			name:  "not in SHA256SUMS",
+			files: map[string][]byte{name: content, bundleManifestName: manifest, bundleSumsName: []byte(good + "  other.tar.gz\n")},
+		},
+		{
+			name:  "no SHA256SUMS",
+			files: map[string][]byte{name: content, bundleManifestName: manifest},
+		},
+		{
+			name:  "no manifest",
+			files: map[string][]byte{name: content, bundleSumsName: sums},
+		},
+		{
+			name:  "missing archive",
+			files: map[string][]byte{bundleManifestName: manifest, bundleSumsName: sums},
+		},
+		{
+			name:  "unsafe path",
+			files: map[string][]byte{"../" + name: content, bundleManifestName: manifest, bundleSumsName: sums},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bf := &bytes.Buffer{}
+			tw := tar.NewWriter(bf)
+			for n, content := range tt.files {
+				fst.NoError(t, writeTarBytes(tw, n, content))
+			}
+			fst.NoError(t, tw.Close())
+			fp := filepath.Join(t.TempDir(), "bundle.tar")
+			fst.NoError(t, os.WriteFile(fp, bf.Bytes(), 0644))
+
+			_, err := m.ImportBundle(context.Background(), fp)
+			fst.Error(t, err)
+			_, err = os.Stat(filepath.Join(m.BundleDir(), name))
+			fst.True(t, os.IsNotExist(err))
+		})
+	}
+}
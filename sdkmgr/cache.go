@@ -0,0 +1,374 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultCacheSize 打包文件缓存默认的大小上限，4 GiB
+const DefaultCacheSize int64 = 4 << 30
+
+// CacheEntry 缓存中的一个打包文件
+type CacheEntry struct {
+	// Name 官方打包文件名，如 go1.22.5.linux-amd64.tar.gz
+	Name string
+
+	SHA256 string
+
+	Size int64
+
+	// LastUsed 最后一次写入或者安装使用的时间，超出大小上限时先淘汰最久未使用的
+	LastUsed time.Time
+
+	// Path 缓存文件的路径
+	Path string
+}
+
+// cacheRecord 打包文件名到缓存文件的记录，保存在 {CacheDir}/names/{name}.json
+type cacheRecord struct {
+	SHA256          string `json:"sha256"`
+	File            string `json:"file"`
+	StripComponents uint   `json:"strip_components"`
+}
+
+// CacheDir 打包文件缓存目录
+//
+// 缓存以 SHA256 寻址，文件保存在 {CacheDir}/blobs/{sha256}.tar.gz 中，
+// 可以配置为多个用户、多个容器共享的目录
+func (m *Manager) CacheDir() string {
+	return m.opts.CacheDir
+}
+
+func (m *Manager) cacheEnabled() bool {
+	return m.opts.CacheSize >= 0
+}
+
+func (m *Manager) cacheSizeLimit() int64 {
+	if m.opts.CacheSize == 0 {
+		return DefaultCacheSize
+	}
+	return m.opts.CacheSize
+}
+
+func (m *Manager) cacheRecordPath(name string) string {
+	return filepath.Join(m.opts.CacheDir, "names", name+".json")
+}
+
+func (m *Manager) cacheBlobDir() string {
+	return filepath.Join(m.opts.CacheDir, "blobs")
+}
+
+var _ LocalFetcher = (*archiveCache)(nil)
+
+// archiveCache 从缓存中获取打包文件，总是最先使用
+type archiveCache struct {
+	m *Manager
+}
+
+func (c *archiveCache) Name() string {
+	return "cache:" + c.m.CacheDir()
+}
+
+func (c *archiveCache) Local() bool {
+	return true
+}
+
+func (c *archiveCache) Fetch(ctx context.Context, req *FetchRequest) (*Archive, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !c.m.cacheEnabled() {
+		return nil, fmt.Errorf("cache disabled: %w", fs.ErrNotExist)
+	}
+	name := req.ArchiveName()
+	rec, err := c.m.readCacheRecord(name)
+	if err != nil {
+		return nil, err
+	}
+	fp := filepath.Join(c.m.cacheBlobDir(), rec.File)
+	sum, err := fileSHA256(ctx, fp)
+	if err != nil {
+		return nil, err
+	}
+	if sum != rec.SHA256 {
+		_ = os.Remove(fp)
+		_ = os.Remove(c.m.cacheRecordPath(name))
+		return nil, fmt.Errorf("cached %s is broken, removed: %w", name, fs.ErrNotExist)
+	}
+	now := time.Now()
+	_ = os.Chtimes(fp, now, now)
+	return &Archive{Path: fp, StripComponents: rec.StripComponents}, nil
+}
+
+func (m *Manager) readCacheRecord(name string) (*cacheRecord, error) {
+	bf, err := os.ReadFile(m.cacheRecordPath(name))
+	if err != nil {
+		return nil, err
+	}
+	rec := &cacheRecord{}
+	if err = json.Unmarshal(bf, rec); err != nil {
+		return nil, err
+	}
+	if len(rec.File) == 0 || rec.File != filepath.Base(rec.File) {
+		return nil, fmt.Errorf("invalid cache record for %s", name)
+	}
+	return rec, nil
+}
+
+// cacheArchive 将下载的临时打包文件移动到缓存中，失败时只输出日志
+func (m *Manager) cacheArchive(ctx context.Context, req *FetchRequest, ar *Archive) {
+	if !m.cacheEnabled() || !ar.Temporary {
+		return
+	}
+	name := req.ArchiveName()
+	if err := m.putCache(ctx, name, ar); err != nil {
+		m.logPrint("cache", "put", name, "failed:", err)
+		return
+	}
+	if _, err := m.CachePrune(ctx); err != nil {
+		m.logPrint("cache", "prune failed:", err)
+	}
+}
+
+func (m *Manager) putCache(ctx context.Context, name string, ar *Archive) error {
+	sum, err := fileSHA256(ctx, ar.Path)
+	if err != nil {
+		return err
+	}
+	ext := ".tar.gz"
+	if strings.HasSuffix(ar.Path, ".zip") {
+		ext = ".zip"
+	}
+	rec := &cacheRecord{
+		SHA256:          sum,
+		File:            sum + ext,
+		StripComponents: ar.StripComponents,
+	}
+	if err = os.MkdirAll(m.cacheBlobDir(), 0755); err != nil {
+		return err
+	}
+	// 和 CachePrune 互斥，避免刚写入还没有记录的文件被当作无效文件删除
+	unlock, err := lockFile(ctx, filepath.Join(m.opts.CacheDir, ".lock"))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err = os.MkdirAll(filepath.Dir(m.cacheRecordPath(name)), 0755); err != nil {
+		return err
+	}
+	fp := filepath.Join(m.cacheBlobDir(), rec.File)
+	if _, err = os.Stat(fp); err != nil {
+		// 先写入临时文件再重命名，中断时不会留下不完整的文件
+		tmp := fp + fmt.Sprintf(".%d.tmp", os.Getpid())
+		if err = os.Rename(ar.Path, tmp); err != nil {
+			// 可能不在同一个文件系统中
+			if err = m.copyFile(ar.Path, tmp); err != nil {
+				_ = os.Remove(tmp)
+				return err
+			}
+		}
+		if err = os.Rename(tmp, fp); err != nil {
+			_ = os.Remove(tmp)
+			return err
+		}
+	} else {
+		now := time.Now()
+		_ = os.Chtimes(fp, now, now)
+	}
+	bf, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	rp := m.cacheRecordPath(name)
+	tmp := rp + fmt.Sprintf(".%d.tmp", os.Getpid())
+	if err = os.WriteFile(tmp, bf, 0644); err != nil {
+		return err
+	}
+	m.logPrint("cache", "put", name, "sha256=", sum)
+	return os.Rename(tmp, rp)
+}
+
+// CacheList 缓存中所有的打包文件，按照最后使用时间倒序排列
+func (m *Manager) CacheList(ctx context.Context) ([]*CacheEntry, error) {
+	entries, err := os.ReadDir(filepath.Join(m.opts.CacheDir, "names"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var result []*CacheEntry
+	for _, e := range entries {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		rec, err := m.readCacheRecord(name)
+		if err != nil {
+			continue
+		}
+		fp := filepath.Join(m.cacheBlobDir(), rec.File)
+		info, err := os.Stat(fp)
+		if err != nil {
+			continue
+		}
+		result = append(result, &CacheEntry{
+			Name:     name,
+			SHA256:   rec.SHA256,
+			Size:     info.Size(),
+			LastUsed: info.ModTime(),
+			Path:     fp,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].LastUsed.After(result[j].LastUsed)
+	})
+	return result, nil
+}
+
+// CachePrune 淘汰最久未使用的打包文件，直到缓存总大小不超过上限，
+// 同时清理无效的记录和没有记录的文件，返回被淘汰的打包文件
+func (m *Manager) CachePrune(ctx context.Context) ([]*CacheEntry, error) {
+	if _, err := os.Stat(m.opts.CacheDir); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	unlock, err := lockFile(ctx, filepath.Join(m.opts.CacheDir, ".lock"))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	list, err := m.CacheList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 一个文件可能有多个名称，按照文件统计大小
+	blobs := make(map[string][]*CacheEntry)
+	var paths []string
+	var total int64
+	for _, e := range list {
+		if _, ok := blobs[e.Path]; !ok {
+			paths = append(paths, e.Path)
+			total += e.Size
+		}
+		blobs[e.Path] = append(blobs[e.Path], e)
+	}
+
+	var removed []*CacheEntry
+	limit := m.cacheSizeLimit()
+	if !m.cacheEnabled() {
+		limit = 0
+	}
+	// list 是按照最后使用时间倒序排列的，从最后开始淘汰
+	for i := len(paths) - 1; i >= 0 && total > limit; i-- {
+		fp := paths[i]
+		es := blobs[fp]
+		if err = os.Remove(fp); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, err
+		}
+		total -= es[0].Size
+		delete(blobs, fp)
+		for _, e := range es {
+			m.logPrint("cache", "evict", e.Name)
+			_ = os.Remove(m.cacheRecordPath(e.Name))
+		}
+		removed = append(removed, es...)
+	}
+	m.cacheRemoveOrphans(blobs)
+	return removed, nil
+}
+
+// cacheRemoveOrphans 删除没有记录的文件、文件已不存在的记录，以及残留的临时文件
+func (m *Manager) cacheRemoveOrphans(blobs map[string][]*CacheEntry) {
+	files, _ := os.ReadDir(m.cacheBlobDir())
+	for _, f := range files {
+		fp := filepath.Join(m.cacheBlobDir(), f.Name())
+		if _, ok := blobs[fp]; ok {
+			continue
+		}
+		info, err := f.Info()
+		// 其他进程可能正在写入
+		if err != nil || (strings.HasSuffix(f.Name(), ".tmp") && time.Since(info.ModTime()) < time.Hour) {
+			continue
+		}
+		m.logPrint("cache", "remove orphan", f.Name())
+		_ = os.Remove(fp)
+	}
+	names, _ := os.ReadDir(filepath.Join(m.opts.CacheDir, "names"))
+	for _, e := range names {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok {
+			continue
+		}
+		rec, err := m.readCacheRecord(name)
+		if err == nil {
+			if _, err = os.Stat(filepath.Join(m.cacheBlobDir(), rec.File)); err == nil {
+				continue
+			}
+		}
+		_ = os.Remove(m.cacheRecordPath(name))
+	}
+}
+
+// CacheClear 删除缓存中所有的打包文件
+func (m *Manager) CacheClear(ctx context.Context) error {
+	if _, err := os.Stat(m.opts.CacheDir); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	unlock, err := lockFile(ctx, filepath.Join(m.opts.CacheDir, ".lock"))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	for _, sub := range []string{"names", "blobs"} {
+		if err = os.RemoveAll(filepath.Join(m.opts.CacheDir, sub)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fileSHA256(ctx context.Context, fp string) (string, error) {
+	f, err := os.Open(fp)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, &ctxReader{ctx: ctx, r: f}); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ctxReader ctx 取消后，读取会返回 ctx.Err()
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,89 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+func TestManager_Cache(t *testing.T) {
+	hits := make(map[string]int)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := path.Base(r.URL.Path)
+		hits[name]++
+		_, _ = w.Write(archiveOf(t, name))
+	}))
+	defer ts.Close()
+
+	cacheDir := t.TempDir()
+	newManager := func(size int64) *Manager {
+		m, err := New(Options{
+			SDKDir:    t.TempDir(),
+			CacheDir:  cacheDir,
+			CacheSize: size,
+			Mirrors:   []string{ts.URL},
+		})
+		fst.NoError(t, err)
+		return m
+	}
+	ctx := context.Background()
+	v1 := mustParseVersion(t, "go1.22.5")
+	v2 := mustParseVersion(t, "go1.23.1")
+	name1 := v1.ArchiveName(runtime.GOOS, runtime.GOARCH)
+	name2 := v2.ArchiveName(runtime.GOOS, runtime.GOARCH)
+
+	m := newManager(0)
+	fst.NoError(t, m.Download(ctx, v1))
+	fst.Equal(t, 1, hits[name1])
+
+	// 删除后重新安装，或者其他用户使用相同的缓存目录安装，都不会重复下载
+	fst.NoError(t, m.Remove(ctx, v1.Raw, RemoveOptions{}))
+	fst.NoError(t, m.Download(ctx, v1))
+	fst.NoError(t, newManager(0).Download(ctx, v1))
+	fst.Equal(t, 1, hits[name1])
+
+	list, err := m.CacheList(ctx)
+	fst.NoError(t, err)
+	fst.Equal(t, 1, len(list))
+	fst.Equal(t, name1, list[0].Name)
+	fst.Equal(t, 64, len(list[0].SHA256))
+
+	// 缓存的文件损坏时会重新下载
+	fst.NoError(t, os.WriteFile(list[0].Path, []byte("broken"), 0644))
+	fst.NoError(t, newManager(0).Download(ctx, v1))
+	fst.Equal(t, 2, hits[name1])
+
+	// 超出大小上限时，淘汰最久未使用的
+	old := time.Now().Add(-time.Hour)
+	list, err = m.CacheList(ctx)
+	fst.NoError(t, err)
+	fst.NoError(t, os.Chtimes(list[0].Path, old, old))
+	fst.NoError(t, newManager(list[0].Size+1).Download(ctx, v2))
+	list, err = m.CacheList(ctx)
+	fst.NoError(t, err)
+	fst.Equal(t, 1, len(list))
+	fst.Equal(t, name2, list[0].Name)
+
+	// 不缓存
+	fst.NoError(t, newManager(-1).Download(ctx, v1))
+	fst.Equal(t, 3, hits[name1])
+	list, err = m.CacheList(ctx)
+	fst.NoError(t, err)
+	fst.Equal(t, 1, len(list))
+
+	fst.NoError(t, m.CacheClear(ctx))
+	list, err = m.CacheList(ctx)
+	fst.NoError(t, err)
+	fst.Empty(t, list)
+}
@@ -0,0 +1,89 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var _ Index = (*Dir)(nil)
+var _ LocalFetcher = (*Dir)(nil)
+
+// Dir 使用本地目录中的官方打包文件作为版本列表和打包文件的来源，
+// 目录中的文件如 go1.22.5.linux-amd64.tar.gz、go1.22.5.windows-amd64.zip
+type Dir struct {
+	Path string
+}
+
+func newDir(path string) (*Dir, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Dir{Path: path}, nil
+}
+
+// Name 名称
+func (d *Dir) Name() string {
+	return "dir:" + d.Path
+}
+
+// Local 本地目录，离线模式下也可以使用
+func (d *Dir) Local() bool {
+	return true
+}
+
+// Refresh 本地目录不需要更新
+func (d *Dir) Refresh(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Versions 目录中所有打包文件的版本号
+func (d *Dir) Versions(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(d.Path)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var vs []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !(strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".zip")) {
+			continue
+		}
+		// go1.22.5.linux-amd64.tar.gz -> go1.22.5
+		i := strings.Index(name, "-")
+		if i < 0 {
+			continue
+		}
+		name = name[:i]
+		if j := strings.LastIndex(name, "."); j > 0 {
+			name = name[:j]
+		}
+		if _, err := ParseVersion(name); err == nil && !seen[name] {
+			seen[name] = true
+			vs = append(vs, name)
+		}
+	}
+	return vs, nil
+}
+
+// Fetch 直接使用目录中的打包文件
+func (d *Dir) Fetch(ctx context.Context, req *FetchRequest) (*Archive, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	fp := filepath.Join(d.Path, req.ArchiveName())
+	if _, err := os.Stat(fp); err != nil {
+		return nil, err
+	}
+	return &Archive{Path: fp, StripComponents: 1}, nil
+}
@@ -0,0 +1,485 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// Check 诊断的一个检查项，见 Manager.Doctor
+type Check struct {
+	// Name 检查项的名称，如 gobin-links
+	Name string `json:"name"`
+
+	// Title 检查的内容
+	Title string `json:"title"`
+
+	Problems []*Problem `json:"problems,omitempty"`
+}
+
+// OK 是否没有问题，或者问题都已修复
+func (c *Check) OK() bool {
+	for _, p := range c.Problems {
+		if !p.Fixed {
+			return false
+		}
+	}
+	return true
+}
+
+// Problem 检查发现的一个问题
+type Problem struct {
+	// Path 有问题的文件或者目录
+	Path string `json:"path,omitempty"`
+
+	Message string `json:"message"`
+
+	// Fix 修复的方式，为空时不能自动修复，需要手动处理
+	Fix string `json:"fix,omitempty"`
+
+	Fixed bool `json:"fixed"`
+
+	// Error 修复失败的原因
+	Error string `json:"error,omitempty"`
+
+	fix func() error
+}
+
+// staleStaging 没有对应锁文件的临时目录（如 .archive-*.staging）超过此时间未修改时，才认为是遗留的
+const staleStaging = time.Hour
+
+// Doctor 检查整个安装：$GOBIN 中的软链、次要版本的软链、SDK 是否完整解压、遗留的打包文件和临时目录、
+// golang/dl 仓库，以及 $GOBIN 是否在 $PATH 中。fix 为 true 时修复可以自动修复的问题
+//
+// 各检查项依次进行，修复后再进行下一项，如删除未完整解压的 SDK 后，再检查指向它的软链
+func (m *Manager) Doctor(ctx context.Context, fix bool) ([]*Check, error) {
+	items := []struct {
+		name  string
+		title string
+		check func(ctx context.Context) ([]*Problem, error)
+	}{
+		{"sdk", "installed SDKs are completely unpacked", m.checkUnpacked},
+		{"leftovers", "no leftover archives or temporary directories", m.checkLeftovers},
+		{"index", "the golang/dl checkout is usable", m.checkGitIndex},
+		{"gobin-links", "links in $GOBIN run smart-go-dl and installed versions", m.checkLinks},
+		{"minor-links", "$GOBIN/go1.x links to the newest installed patch version", m.checkMinorLinks},
+		{"path", "$GOBIN is in $PATH and its go comes first", m.checkPATH},
+	}
+	var checks []*Check
+	for _, item := range items {
+		ps, err := item.check(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("check %s: %w", item.name, err)
+		}
+		for _, p := range ps {
+			if !fix || p.fix == nil {
+				continue
+			}
+			if err = p.fix(); err != nil {
+				p.Error = err.Error()
+			} else {
+				p.Fixed = true
+			}
+			m.logPrint("doctor", "fix", p.Path, p.Fix, "error=", p.Error)
+		}
+		checks = append(checks, &Check{Name: item.name, Title: item.title, Problems: ps})
+	}
+	return checks, nil
+}
+
+// checkUnpacked 安装目录中没有 unpackedOkay 标记文件的 SDK，即解压、构建未完成的，
+// 修复时删除 smart-go-dl 安装的，只读的 SharedSDKDirs 中的不会修复
+func (m *Manager) checkUnpacked(ctx context.Context) ([]*Problem, error) {
+	var ps []*Problem
+	for _, root := range m.SDKDirs() {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range entries {
+			if err = ctx.Err(); err != nil {
+				return nil, err
+			}
+			name, ok := strings.CutSuffix(e.Name(), m.platformSuffix())
+			if !ok || !e.IsDir() {
+				continue
+			}
+			v, err := ParseVersion(name)
+			if err != nil {
+				continue
+			}
+			gr := filepath.Join(root, e.Name())
+			// 之前版本构建的 gotip 没有标记文件
+			if fileExists(filepath.Join(gr, unpackedOkay)) || fileExists(filepath.Join(gr, tipInfoFile)) {
+				continue
+			}
+			p := &Problem{
+				Path:    gr,
+				Message: fmt.Sprintf("%s is not completely unpacked, missing %s", v.Raw, unpackedOkay),
+			}
+			switch {
+			case root != m.opts.SDKDir:
+				p.Message += ", in the read-only " + root
+			case m.checkOwned(gr) != nil:
+				p.Message += ", not installed by smart-go-dl, adopt or remove it"
+			default:
+				p.Fix = fmt.Sprintf("remove it, then run 'smart-go-dl install %s' again", v.Raw)
+				p.fix = func() error {
+					unlock := m.lockVersion(gr)
+					defer unlock()
+					return os.RemoveAll(gr)
+				}
+			}
+			ps = append(ps, p)
+		}
+	}
+	return ps, nil
+}
+
+// checkLeftovers 被中断（如 kill -9）时遗留的临时目录、打包文件：
+// SDKDir 中的 .*.staging 目录和打包文件，之前版本下载到 GOROOT 中的打包文件，DataDir 中的 golang_dl.tar.gz
+func (m *Manager) checkLeftovers(ctx context.Context) ([]*Problem, error) {
+	var files []string
+	entries, err := os.ReadDir(m.opts.SDKDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		switch {
+		case strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".staging"):
+			if !m.stagingInUse(filepath.Join(m.opts.SDKDir, name)) {
+				files = append(files, filepath.Join(m.opts.SDKDir, name))
+			}
+		case isArchiveName(name) || strings.HasSuffix(name, ".part"):
+			files = append(files, filepath.Join(m.opts.SDKDir, name))
+		}
+	}
+	sdks, err := m.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range sdks {
+		if s.Shared {
+			continue
+		}
+		ms, _ := filepath.Glob(filepath.Join(s.GOROOT, "go1*"))
+		for _, fp := range ms {
+			if isArchiveName(filepath.Base(fp)) {
+				files = append(files, fp)
+			}
+		}
+	}
+	if fp := filepath.Join(m.opts.DataDir, "golang_dl.tar.gz"); fileExists(fp) {
+		files = append(files, fp)
+	}
+
+	var ps []*Problem
+	for _, fp := range files {
+		ps = append(ps, &Problem{
+			Path:    fp,
+			Message: "leftover of an interrupted install",
+			Fix:     "remove it",
+			fix: func() error {
+				return os.RemoveAll(fp)
+			},
+		})
+	}
+	return ps, nil
+}
+
+// stagingInUse 临时目录是否正在使用：{SDKDir}/.go1.22.5.staging 对应的 .go1.22.5.lock 被持有，
+// 或者没有对应的锁文件，最近修改过
+func (m *Manager) stagingInUse(dir string) bool {
+	lock := strings.TrimSuffix(dir, ".staging") + ".lock"
+	if fileExists(lock) {
+		return !staleLockFile(lock)
+	}
+	info, err := os.Stat(dir)
+	return err == nil && time.Since(info.ModTime()) < staleStaging
+}
+
+func isArchiveName(name string) bool {
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".zip")
+}
+
+// checkGitIndex 检查 DataDir 中的 golang/dl 仓库，没有版本或者不是 git 仓库时，
+// 修复时删除该仓库，并清除版本列表的更新时间，下次更新版本列表时会重新下载
+func (m *Manager) checkGitIndex(ctx context.Context) ([]*Problem, error) {
+	var ps []*Problem
+	for _, idx := range m.indexes {
+		gi, ok := idx.(*GitIndex)
+		if !ok {
+			continue
+		}
+		dir := gi.dir()
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+		var msg string
+		if vs, err := gi.Versions(ctx); err != nil {
+			return nil, err
+		} else if len(vs) == 0 {
+			msg = "no go versions in it"
+		} else if _, err = git.PlainOpen(dir); err != nil {
+			msg = "not a git repository: " + err.Error()
+		}
+		if len(msg) == 0 {
+			continue
+		}
+		ps = append(ps, &Problem{
+			Path:    dir,
+			Message: msg,
+			Fix:     "remove it, it is downloaded again on the next refresh",
+			fix: func() error {
+				if err := os.RemoveAll(dir); err != nil {
+					return err
+				}
+				err := os.Remove(filepath.Join(m.opts.DataDir, indexStatusFile))
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			},
+		})
+	}
+	return ps, nil
+}
+
+// checkLinks 检查 $GOBIN 中的 go、go.latest、go1.x、gotip 等软链：
+// 软链失效的、指向其他位置的 smart-go-dl（如移动了 smart-go-dl 程序）的、对应的版本未安装的、
+// go.latest 不是已安装的最新版本的，以及已安装的版本缺少的软链。windows 下是复制的文件，不检查
+func (m *Manager) checkLinks(ctx context.Context) ([]*Problem, error) {
+	if len(m.opts.GOBIN) == 0 || len(m.opts.Shim) == 0 || isWindows() {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(m.opts.GOBIN)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	shim, _ := filepath.EvalSymlinks(m.opts.Shim)
+	relink := func() error {
+		return m.LinkLatest(ctx)
+	}
+	var ps []*Problem
+	for _, e := range entries {
+		name := e.Name()
+		if !isShimName(name) || e.Type()&os.ModeSymlink == 0 {
+			continue
+		}
+		fp := filepath.Join(m.opts.GOBIN, name)
+		hop, target := m.lastLink(fp)
+		p := &Problem{Path: fp}
+		if _, err = os.Stat(target); err != nil {
+			p.Message = "dangling link to " + target
+		} else if resolved, _ := filepath.EvalSymlinks(target); resolved != shim {
+			if base := filepath.Base(resolved); !strings.HasPrefix(base, "smart-go-dl") && base != filepath.Base(shim) {
+				// 由用户创建的软链，如链接到 /usr/local/go/bin/go
+				continue
+			}
+			p.Message = fmt.Sprintf("links to %s, not %s", resolved, m.opts.Shim)
+			p.Fix = "link " + hop + " to " + m.opts.Shim
+			p.fix = func() error {
+				return m.createLink(m.opts.Shim, hop)
+			}
+			ps = append(ps, p)
+			continue
+		} else if version := m.linkedVersion(fp); len(version) == 0 {
+			continue
+		} else if _, err = m.ResolveLink(ctx, version); err != nil {
+			p.Message = version + " is not installed"
+		} else {
+			continue
+		}
+		p.Fix = "remove it and recreate the links of installed versions"
+		p.fix = func() error {
+			if err := os.Remove(fp); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return relink()
+		}
+		ps = append(ps, p)
+	}
+
+	sdks, err := m.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, s := range sdks {
+		if !s.Version.IsTip() && !linkExists(m.BinPath(s.Version)) {
+			missing = append(missing, m.BinPath(s.Version))
+		}
+	}
+	if len(sdks) > 0 {
+		for _, name := range []string{"go.latest", "go"} {
+			if fp := filepath.Join(m.opts.GOBIN, name+exe()); !linkExists(fp) {
+				missing = append(missing, fp)
+			}
+		}
+	}
+	latestPath := filepath.Join(m.opts.GOBIN, "go.latest"+exe())
+	if latest := latestVersion(sdks); latest != nil && linkExists(latestPath) {
+		if link, err := os.Readlink(latestPath); err == nil && filepath.Base(link) != filepath.Base(m.MinorBinPath(latest)) {
+			ps = append(ps, &Problem{
+				Path:    latestPath,
+				Message: fmt.Sprintf("links to %s, the newest installed is %s", filepath.Base(link), latest.Raw),
+				Fix:     "link it to " + filepath.Base(m.MinorBinPath(latest)),
+				fix:     relink,
+			})
+		}
+	}
+	for _, fp := range missing {
+		ps = append(ps, &Problem{
+			Path:    fp,
+			Message: "missing",
+			Fix:     "create the links of installed versions",
+			fix:     relink,
+		})
+	}
+	return ps, nil
+}
+
+// isShimName 是否 $GOBIN 中由 smart-go-dl 创建的命令，如 go、go.latest、go1.22、go1.22.5、gotip
+func isShimName(name string) bool {
+	name = strings.TrimSuffix(name, exe())
+	return name == "go" || name == "go.latest" || name == "gotip" || strings.HasPrefix(name, "go1.")
+}
+
+// lastLink 沿着 $GOBIN 中的软链，返回最后一个在 $GOBIN 中的软链，及其指向的位置，
+// 如 go -> go.latest -> go1.22 -> go1.22.5 -> ~/go/bin/smart-go-dl 中的 go1.22.5 和 smart-go-dl
+func (m *Manager) lastLink(fp string) (string, string) {
+	for range 10 {
+		link, err := os.Readlink(fp)
+		if err != nil {
+			return fp, fp
+		}
+		if !filepath.IsAbs(link) {
+			link = filepath.Join(filepath.Dir(fp), link)
+		}
+		if filepath.Dir(link) != m.opts.GOBIN {
+			return fp, link
+		}
+		if _, err = os.Lstat(link); err != nil {
+			return fp, link
+		}
+		fp = link
+	}
+	return fp, fp
+}
+
+// checkMinorLinks 检查 $GOBIN/go1.x 是否链接到次要版本中已安装的最新版本，如 go1.22 -> go1.22.5，
+// 变体版本为其分组，如 go1.22-acme。windows 下是复制的文件，不检查
+func (m *Manager) checkMinorLinks(ctx context.Context) ([]*Problem, error) {
+	if len(m.opts.GOBIN) == 0 || len(m.opts.Shim) == 0 || isWindows() {
+		return nil, nil
+	}
+	sdks, err := m.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ps []*Problem
+	seen := make(map[string]bool)
+	// sdks 是按照版本倒序排列的，每个次要版本第一个即是最新的
+	for _, s := range sdks {
+		v := s.Version
+		if v.IsTip() || seen[v.Normalized] {
+			continue
+		}
+		seen[v.Normalized] = true
+		minor, want := m.MinorBinPath(v), m.BinPath(v)
+		if minor == want {
+			continue
+		}
+		p := &Problem{
+			Path: minor,
+			Fix:  "link it to " + filepath.Base(want),
+			fix: func() error {
+				return m.createLink(want, minor)
+			},
+		}
+		info, err := os.Lstat(minor)
+		if err != nil {
+			p.Message = "missing, the newest installed is " + v.Raw
+			ps = append(ps, p)
+			continue
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+		link, err := os.Readlink(minor)
+		if err != nil {
+			return nil, err
+		}
+		if !filepath.IsAbs(link) {
+			link = filepath.Join(filepath.Dir(minor), link)
+		}
+		if link != want {
+			p.Message = fmt.Sprintf("links to %s, the newest installed is %s", filepath.Base(link), v.Raw)
+			ps = append(ps, p)
+		}
+	}
+	return ps, nil
+}
+
+// checkPATH 检查 $GOBIN 是否在 $PATH 中，以及 $PATH 中 $GOBIN 之前是否有其他的 go，
+// 需要手动修改 shell 的配置文件，不能自动修复
+func (m *Manager) checkPATH(ctx context.Context) ([]*Problem, error) {
+	if len(m.opts.GOBIN) == 0 {
+		return nil, nil
+	}
+	var first string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if len(dir) == 0 {
+			continue
+		}
+		if sameDir(dir, m.opts.GOBIN) {
+			if len(first) == 0 || !fileExists(filepath.Join(m.opts.GOBIN, "go"+exe())) {
+				return nil, nil
+			}
+			return []*Problem{{
+				Path:    first,
+				Message: fmt.Sprintf("found before %s in $PATH, 'go' does not run smart-go-dl", m.opts.GOBIN),
+			}}, nil
+		}
+		if fp := filepath.Join(dir, "go"+exe()); len(first) == 0 && fileExists(fp) {
+			first = fp
+		}
+	}
+	return []*Problem{{
+		Path:    m.opts.GOBIN,
+		Message: fmt.Sprintf("not in $PATH, add it to $PATH in your shell profile, eg: export PATH=%s:$PATH", m.opts.GOBIN),
+	}}, nil
+}
+
+func sameDir(a string, b string) bool {
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+	ra, err1 := filepath.EvalSymlinks(a)
+	rb, err2 := filepath.EvalSymlinks(b)
+	return err1 == nil && err2 == nil && ra == rb
+}
+
+func fileExists(fp string) bool {
+	_, err := os.Stat(fp)
+	return err == nil
+}
+
+// linkExists 文件是否存在，失效的软链也是存在的
+func linkExists(fp string) bool {
+	_, err := os.Lstat(fp)
+	return err == nil
+}
@@ -0,0 +1,103 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+func TestManager_Doctor(t *testing.T) {
+	if isWindows() {
+		t.Skip("links are copied files on windows")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	shim := filepath.Join(dir, "smart-go-dl")
+	fst.NoError(t, os.WriteFile(shim, []byte("shim"), 0755))
+	m, err := New(Options{
+		SDKDir: filepath.Join(dir, "sdk"),
+		GOBIN:  filepath.Join(dir, "bin"),
+		Shim:   shim,
+	})
+	fst.NoError(t, err)
+	t.Setenv("PATH", m.GOBIN())
+
+	for _, version := range []string{"go1.22.1", "go1.22.5", "go1.21.0"} {
+		fakeInstall(t, m, version)
+		v, _ := ParseVersion(version)
+		if version != "go1.21.0" {
+			fst.NoError(t, os.WriteFile(filepath.Join(m.GOROOT(v), unpackedOkay), nil, 0644))
+		}
+	}
+	// 中断后遗留的临时目录和打包文件
+	staging := filepath.Join(m.SDKDir(), ".archive-1.staging")
+	fst.NoError(t, os.MkdirAll(staging, 0755))
+	old := time.Now().Add(-2 * staleStaging)
+	fst.NoError(t, os.Chtimes(staging, old, old))
+	fst.NoError(t, os.WriteFile(filepath.Join(m.SDKDir(), "go1.20.linux-amd64.tar.gz"), nil, 0644))
+
+	// 移动过的 smart-go-dl、失效的软链、不是最新版本的次要版本软链
+	oldShim := filepath.Join(dir, "old", "smart-go-dl")
+	fst.NoError(t, os.MkdirAll(filepath.Dir(oldShim), 0755))
+	fst.NoError(t, os.WriteFile(oldShim, []byte("shim"), 0755))
+	fst.NoError(t, os.MkdirAll(m.GOBIN(), 0755))
+	fst.NoError(t, os.Symlink(oldShim, filepath.Join(m.GOBIN(), "go1.22.5")))
+	fst.NoError(t, os.Symlink(shim, filepath.Join(m.GOBIN(), "go1.22.1")))
+	fst.NoError(t, os.Symlink("go1.22.1", filepath.Join(m.GOBIN(), "go1.22")))
+	fst.NoError(t, os.Symlink(filepath.Join(dir, "404"), filepath.Join(m.GOBIN(), "go1.20")))
+	// 用户自己创建的软链不处理
+	fst.NoError(t, os.WriteFile(filepath.Join(dir, "go"), nil, 0755))
+	fst.NoError(t, os.Symlink(filepath.Join(dir, "go"), filepath.Join(m.GOBIN(), "go1.19")))
+
+	problems := func(checks []*Check) map[string][]string {
+		got := make(map[string][]string)
+		for _, c := range checks {
+			for _, p := range c.Problems {
+				if !p.Fixed {
+					got[c.Name] = append(got[c.Name], filepath.Base(p.Path))
+				}
+			}
+		}
+		return got
+	}
+
+	checks, err := m.Doctor(ctx, false)
+	fst.NoError(t, err)
+	got := problems(checks)
+	fst.Equal(t, []string{"go1.21.0"}, got["sdk"])
+	fst.Equal(t, []string{".archive-1.staging", "go1.20.linux-amd64.tar.gz"}, got["leftovers"])
+	fst.Equal(t, []string{"go1.20", "go1.22.5", "go1.21.0", "go.latest", "go"}, got["gobin-links"])
+	fst.Equal(t, []string{"go1.22", "go1.21"}, got["minor-links"])
+	fst.Empty(t, got["path"])
+
+	checks, err = m.Doctor(ctx, true)
+	fst.NoError(t, err)
+	fst.Empty(t, problems(checks))
+
+	checks, err = m.Doctor(ctx, false)
+	fst.NoError(t, err)
+	for _, c := range checks {
+		fst.Empty(t, c.Problems)
+	}
+	link, err := os.Readlink(filepath.Join(m.GOBIN(), "go1.22"))
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.5", link)
+	_, err = os.Stat(filepath.Join(m.SDKDir(), "go1.21.0"))
+	fst.True(t, os.IsNotExist(err))
+	_, err = os.Lstat(filepath.Join(m.GOBIN(), "go1.19"))
+	fst.NoError(t, err)
+
+	// $PATH 中 $GOBIN 之前有其他的 go
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+m.GOBIN())
+	checks, err = m.Doctor(ctx, false)
+	fst.NoError(t, err)
+	fst.Equal(t, []string{"go"}, problems(checks)["path"])
+}
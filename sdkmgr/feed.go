@@ -0,0 +1,119 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// DefaultFeedURL go.dev 的版本列表接口，包含所有的历史版本
+const DefaultFeedURL = "https://go.dev/dl/?mode=json&include=all"
+
+var _ Index = (*FeedIndex)(nil)
+
+// FeedIndex 使用 go.dev 格式的 JSON 接口作为版本列表
+type FeedIndex struct {
+	// URL 接口地址，可选，默认为 DefaultFeedURL
+	URL string
+
+	m *Manager
+}
+
+func (fi *FeedIndex) setManager(m *Manager) {
+	fi.m = m
+}
+
+// Name 名称
+func (fi *FeedIndex) Name() string {
+	if len(fi.URL) == 0 || fi.URL == DefaultFeedURL {
+		return "godev"
+	}
+	return "feed:" + fi.URL
+}
+
+func (fi *FeedIndex) url() string {
+	if len(fi.URL) == 0 {
+		return DefaultFeedURL
+	}
+	return fi.URL
+}
+
+// cachePath 本地缓存文件，如 ~/sdk/smart-go-dl/feed/godev.json
+func (fi *FeedIndex) cachePath() string {
+	name := "godev"
+	if u := fi.url(); u != DefaultFeedURL {
+		sum := sha1.Sum([]byte(u))
+		name = hex.EncodeToString(sum[:8])
+	}
+	return filepath.Join(fi.m.opts.DataDir, "feed", name+".json")
+}
+
+// Refresh 下载版本列表到本地缓存
+func (fi *FeedIndex) Refresh(ctx context.Context) error {
+	fp := fi.cachePath()
+	if err := os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+		return err
+	}
+	if err := fi.m.httpGet(ctx, fi.url(), fp); err != nil {
+		return err
+	}
+	// 校验格式，避免缓存错误的内容
+	if _, err := fi.releases(); err != nil {
+		_ = os.Remove(fp)
+		return err
+	}
+	return nil
+}
+
+// FeedRelease go.dev 版本列表接口中的一个版本
+type FeedRelease struct {
+	Version string     `json:"version"`
+	Stable  bool       `json:"stable"`
+	Files   []FeedFile `json:"files"`
+}
+
+// FeedFile go.dev 版本列表接口中的一个文件
+type FeedFile struct {
+	Filename string `json:"filename"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Version  string `json:"version"`
+	SHA256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+	Kind     string `json:"kind"` // archive、installer、source
+}
+
+func (fi *FeedIndex) releases() ([]FeedRelease, error) {
+	bf, err := os.ReadFile(fi.cachePath())
+	if err != nil {
+		return nil, err
+	}
+	var list []FeedRelease
+	if err = json.Unmarshal(bf, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Versions 本地缓存的所有版本号
+func (fi *FeedIndex) Versions(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	list, err := fi.releases()
+	if err != nil {
+		return nil, err
+	}
+	vs := make([]string, 0, len(list))
+	for _, r := range list {
+		vs = append(vs, r.Version)
+	}
+	return vs, nil
+}
@@ -0,0 +1,74 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lockFile 跨进程的文件锁，文件内容为持有锁的进程的 pid，返回解锁的方法
+// 持有锁的进程已经退出（如被 kill -9）时，会自动清理掉遗留的锁文件
+func lockFile(ctx context.Context, path string) (func(), error) {
+	for {
+		unlock, err := TryLockFile(path)
+		if err != nil || unlock != nil {
+			return unlock, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for lock %s: %w", path, ctx.Err())
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
+
+// TryLockFile 同 lockFile，但是不等待，锁被其他进程持有时返回 nil, nil
+func TryLockFile(path string) (func(), error) {
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, err = fmt.Fprint(f, os.Getpid())
+			if err1 := f.Close(); err == nil {
+				err = err1
+			}
+			if err != nil {
+				_ = os.Remove(path)
+				return nil, err
+			}
+			return func() {
+				_ = os.Remove(path)
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if !staleLockFile(path) {
+			return nil, nil
+		}
+		_ = os.Remove(path)
+	}
+}
+
+func staleLockFile(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	bf, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(bf)))
+	if err != nil {
+		// 刚创建，还没有写入 pid
+		return time.Since(info.ModTime()) > 10*time.Second
+	}
+	return !processAlive(pid)
+}
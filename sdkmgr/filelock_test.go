@@ -0,0 +1,58 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+func Test_lockFile(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "a.lock")
+	unlock, err := lockFile(context.Background(), fp)
+	fst.NoError(t, err)
+
+	// 被当前进程持有时需要等待
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err = lockFile(ctx, fp)
+	fst.ErrorIs(t, err, context.DeadlineExceeded)
+
+	unlock()
+	_, err = os.Stat(fp)
+	fst.True(t, os.IsNotExist(err))
+
+	// 持有锁的进程已经退出时，会清理掉锁文件
+	fst.NoError(t, os.WriteFile(fp, []byte(strconv.Itoa(1<<30)), 0644))
+	unlock, err = lockFile(context.Background(), fp)
+	fst.NoError(t, err)
+	unlock()
+}
+
+func TestTryLockFile(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "a.lock")
+	unlock, err := TryLockFile(fp)
+	fst.NoError(t, err)
+	fst.NotNil(t, unlock)
+
+	// 被持有时不等待
+	unlock2, err := TryLockFile(fp)
+	fst.NoError(t, err)
+	fst.Nil(t, unlock2)
+	unlock()
+
+	// 持有锁的进程已经退出
+	fst.NoError(t, os.WriteFile(fp, []byte(strconv.Itoa(1<<30)), 0644))
+	unlock, err = TryLockFile(fp)
+	fst.NoError(t, err)
+	fst.NotNil(t, unlock)
+	unlock()
+}
@@ -0,0 +1,112 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultGoProxy 默认的 GOPROXY 地址
+const DefaultGoProxy = "https://proxy.golang.org"
+
+// toolchainModule go1.21 开始发布到 GOPROXY 的 Go SDK 模块
+const toolchainModule = "golang.org/toolchain"
+
+var _ Index = (*GoProxy)(nil)
+var _ Fetcher = (*GoProxy)(nil)
+
+// GoProxy 使用 GOPROXY 中的 golang.org/toolchain 模块作为版本列表和打包文件的来源，
+// 和 go 命令的 GOTOOLCHAIN 自动下载一致，只有 go1.21 及之后的版本
+type GoProxy struct {
+	// URL GOPROXY 地址，可选
+	// 为空时使用环境变量 GOPROXY 中的第一个地址，若没有则使用 DefaultGoProxy
+	URL string
+
+	m *Manager
+}
+
+func (gp *GoProxy) setManager(m *Manager) {
+	gp.m = m
+}
+
+// Name 名称
+func (gp *GoProxy) Name() string {
+	return "goproxy:" + gp.url()
+}
+
+func (gp *GoProxy) url() string {
+	if len(gp.URL) > 0 {
+		return strings.TrimSuffix(gp.URL, "/")
+	}
+	for _, p := range strings.FieldsFunc(os.Getenv("GOPROXY"), func(r rune) bool {
+		return r == ',' || r == '|'
+	}) {
+		if p != "direct" && p != "off" {
+			return strings.TrimSuffix(p, "/")
+		}
+	}
+	return DefaultGoProxy
+}
+
+func (gp *GoProxy) cachePath() string {
+	return filepath.Join(gp.m.opts.DataDir, "goproxy.list")
+}
+
+// Refresh 下载 golang.org/toolchain 模块的版本列表到本地缓存
+func (gp *GoProxy) Refresh(ctx context.Context) error {
+	return gp.m.httpGet(ctx, gp.url()+"/"+toolchainModule+"/@v/list", gp.cachePath())
+}
+
+// Versions 本地缓存的所有版本号
+// 模块版本如 v0.0.1-go1.22.5.linux-amd64，会转换为 go1.22.5
+func (gp *GoProxy) Versions(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	bf, err := os.ReadFile(gp.cachePath())
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var vs []string
+	for _, line := range strings.Fields(string(bf)) {
+		name, ok := strings.CutPrefix(line, "v0.0.1-")
+		if !ok {
+			continue
+		}
+		if i := strings.LastIndex(name, "."); i > 0 {
+			name = name[:i]
+		}
+		if !seen[name] {
+			seen[name] = true
+			vs = append(vs, name)
+		}
+	}
+	return vs, nil
+}
+
+// Fetch 下载 golang.org/toolchain 模块的 zip 文件
+func (gp *GoProxy) Fetch(ctx context.Context, req *FetchRequest) (*Archive, error) {
+	v := req.Version
+	if req.Source {
+		return nil, fmt.Errorf("source archive is not published to GOPROXY: %w", fs.ErrNotExist)
+	}
+	if v.Minor < 21 || v.IsLanguage() {
+		return nil, fmt.Errorf("%s is not published to GOPROXY: %w", v.Raw, fs.ErrNotExist)
+	}
+	// 如 v0.0.1-go1.22.5.linux-amd64
+	mv := fmt.Sprintf("v0.0.1-%s.%s-%s", v.Name(), req.GOOS, req.GOARCH)
+	out := filepath.Join(req.Dir, mv+".zip")
+	if err := gp.m.httpGet(ctx, gp.url()+"/"+toolchainModule+"/@v/"+mv+".zip", out); err != nil {
+		return nil, err
+	}
+	// zip 中的文件如 golang.org/toolchain@v0.0.1-go1.22.5.linux-amd64/bin/go
+	return &Archive{Path: out, StripComponents: 2, Temporary: true}, nil
+}
@@ -0,0 +1,167 @@
+// Copyright(C) 2021 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2021/12/31
+
+package sdkmgr
+
+import (
+	"context"
+	_ "embed" // embed file for go version list
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/fsgo/cmdutil"
+	"github.com/go-git/go-git/v5"
+)
+
+const golangDLDir = "golang_dl"
+
+const defaultRepo = "https://github.com/golang/dl.git"
+
+//go:embed files/golang_dl.tar.gz
+var golangDlTar []byte
+
+var _ Index = (*GitIndex)(nil)
+
+// GitIndex 使用 golang/dl 的 git 仓库作为版本列表，仓库中每个版本都有一个同名的目录
+// 首次下载失败时，会使用内置的版本列表
+type GitIndex struct {
+	// Repo 仓库地址，可选，默认为 https://github.com/golang/dl.git
+	Repo string
+
+	m *Manager
+}
+
+func (gi *GitIndex) setManager(m *Manager) {
+	gi.m = m
+}
+
+// Name 名称
+func (gi *GitIndex) Name() string {
+	return "git"
+}
+
+func (gi *GitIndex) repo() string {
+	if len(gi.Repo) == 0 {
+		return defaultRepo
+	}
+	return gi.Repo
+}
+
+func (gi *GitIndex) dir() string {
+	return filepath.Join(gi.m.opts.DataDir, golangDLDir)
+}
+
+// Versions 本地仓库中的所有版本号
+func (gi *GitIndex) Versions(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	matches, err := filepath.Glob(filepath.Join(gi.dir(), "go1.*"))
+	if err != nil {
+		return nil, err
+	}
+	var vs []string
+	for _, name := range matches {
+		vs = append(vs, filepath.Base(name))
+	}
+	return vs, nil
+}
+
+// Refresh 下载或者更新 golang/dl.git
+// 首次下载失败时，会解压内置的版本列表，但依然会返回错误
+func (gi *GitIndex) Refresh(ctx context.Context) error {
+	m := gi.m
+	dlDir := gi.dir()
+	if _, err := os.Stat(dlDir); err == nil {
+		return gi.gitPull(ctx, dlDir)
+	}
+
+	cmdClone := exec.CommandContext(ctx, "git", "clone", gi.repo(), golangDLDir)
+	cmdClone.Dir = m.opts.DataDir
+	setCancel(cmdClone)
+	m.logPrint("exec", cmdClone.String())
+	gi.setGitCmdEnv(cmdClone)
+	cmdClone.Stderr = m.output
+	cmdClone.Stdout = m.output
+
+	if err := cmdClone.Run(); err != nil {
+		if ctx.Err() != nil {
+			// 被中断时删除未下载完成的仓库，下次重新下载
+			_ = os.RemoveAll(dlDir)
+			return ctx.Err()
+		}
+		// 若直接下载失败了，则使用内置的，将其解压到对应目录下去
+		m.logPrint("fallback", "extract", gi.repo(), "by embed datas")
+		if err2 := gi.extractGolangDLTar(dlDir); err2 != nil {
+			return errors.Join(err, err2)
+		}
+		return fmt.Errorf("git clone failed, using embedded version list: %w", err)
+	}
+	return nil
+}
+
+func (gi *GitIndex) setGitCmdEnv(cmd *exec.Cmd) {
+	if gi.m.opts.InsecureSkipVerify {
+		cmd.Env = append(os.Environ(), "GIT_SSL_NO_VERIFY=true")
+	}
+}
+
+func (gi *GitIndex) gitPull(ctx context.Context, dir string) error {
+	m := gi.m
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	if !m.opts.GoGit {
+		cmdPull := exec.CommandContext(ctx, "git", "pull", "-v")
+		cmdPull.Dir = dir
+		setCancel(cmdPull)
+		m.logPrint("exec", cmdPull.String())
+		gi.setGitCmdEnv(cmdPull)
+		cmdPull.Stderr = m.output
+		cmdPull.Stdout = m.output
+		err := cmdPull.Run()
+		if err == nil || ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		m.logPrint("git pull failed, ", err)
+	}
+
+	gr, err := git.PlainOpen(dir)
+	if err != nil {
+		m.logPrint("try open with pure Go git failed,", err)
+		return err
+	}
+	w, err := gr.Worktree()
+	if err != nil {
+		m.logPrint("pure Go git Worktree:", err)
+		return err
+	}
+	err = w.PullContext(ctx, &git.PullOptions{})
+	if err != nil {
+		if errors.Is(err, git.NoErrAlreadyUpToDate) {
+			m.logPrint("pure GoGit:", "git pull ", err)
+			return nil
+		}
+		m.logPrint("pure GoGit:", "git pull ", err)
+	}
+	return err
+}
+
+func (gi *GitIndex) extractGolangDLTar(dstDir string) error {
+	tarPath := filepath.Join(gi.m.opts.DataDir, "golang_dl.tar.gz")
+	defer os.Remove(tarPath)
+
+	if err := os.WriteFile(tarPath, golangDlTar, 0644); err != nil {
+		return err
+	}
+	tr := &cmdutil.Tar{
+		StripComponents: 1,
+	}
+	return tr.Unpack(tarPath, dstDir)
+}
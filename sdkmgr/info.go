@@ -0,0 +1,214 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// usedDir 记录每个版本最后一次使用的时间，在 DataDir 下，文件的修改时间即为最后使用的时间
+const usedDir = "used"
+
+// usedPrecision 最后使用时间的精度，在此时间内重复使用时不再更新，避免每次运行 go 命令都写文件
+const usedPrecision = time.Hour
+
+// MarkUsed 记录版本 v 被使用了，如以 go1.22 别名运行时，见 LastUsed
+func (m *Manager) MarkUsed(v *Version) error {
+	fp := filepath.Join(m.opts.DataDir, usedDir, v.Name()+m.platformSuffix())
+	now := time.Now()
+	info, err := os.Stat(fp)
+	if err == nil && now.Sub(info.ModTime()) < usedPrecision {
+		return nil
+	}
+	if err == nil {
+		return os.Chtimes(fp, now, now)
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fp, nil, 0644)
+}
+
+// LastUsed 版本 v 最后一次使用的时间，没有记录时返回零值
+func (m *Manager) LastUsed(v *Version) time.Time {
+	info, err := os.Stat(filepath.Join(m.opts.DataDir, usedDir, v.Name()+m.platformSuffix()))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// VersionInfo 一个版本的详细信息，见 Manager.Info
+type VersionInfo struct {
+	// Version 版本，如 go1.22.5
+	Version string `json:"version"`
+
+	Installed bool `json:"installed"`
+
+	// GOROOT 已安装时的 GOROOT，原地接管的为软链
+	GOROOT string `json:"goroot,omitempty"`
+
+	// Root 所在的安装目录，Shared 表示是只读的 SharedSDKDirs
+	Root   string `json:"root,omitempty"`
+	Shared bool   `json:"shared,omitempty"`
+
+	// Size GOROOT 占用的磁盘空间，单位字节
+	Size int64 `json:"size,omitempty"`
+
+	// Manifest 安装信息，之前版本安装的、原地接管的没有
+	Manifest *Manifest `json:"manifest,omitempty"`
+
+	Locked bool `json:"locked"`
+
+	// LastUsed 以 go1.x 等别名运行时最后一次使用的时间，见 MarkUsed
+	LastUsed *time.Time `json:"last_used,omitempty"`
+
+	// Links $GOBIN 中会运行该版本的命令，如 go1.22.5、go1.22、gotip
+	//
+	// go、go.latest 运行的是 $PATH 和安装目录中最新的 go，
+	// Info 不会填写，由调用方依据别名的解析添加，如 smart-go-dl info
+	Links []string `json:"links,omitempty"`
+
+	// Latest 是否 go.latest 运行的版本，Info 不会填写，同 Links
+	Latest bool `json:"latest"`
+
+	// Pin 当前目录的项目中指定的 go 版本（go.mod 中的 toolchain、.go-version）为该版本时的指定信息，
+	// 只是说明，smart-go-dl 不会依据它选择版本，Info 不会填写，同 Links
+	Pin *ProjectVersion `json:"pin,omitempty"`
+
+	// Newer 版本列表中同一次要版本更新的修订版本，如 go1.22.6，版本列表需要先使用 Refresh 更新
+	Newer string `json:"newer,omitempty"`
+
+	// Variant 变体版本的构建信息
+	Variant *VariantInfo `json:"variant,omitempty"`
+
+	// Tip gotip 的构建信息
+	Tip *TipInfo `json:"tip,omitempty"`
+}
+
+// Info 查询版本的详细信息，version 为已安装的版本号或者版本约束，未安装时从版本列表中查找
+func (m *Manager) Info(ctx context.Context, version string) (*VersionInfo, error) {
+	var v *Version
+	if sdk, err := m.Resolve(ctx, version); err == nil {
+		v = sdk.Version
+	} else if v, err = m.ResolveRelease(ctx, version); err != nil {
+		return nil, err
+	}
+	info := &VersionInfo{
+		Version:   v.Raw,
+		Installed: m.Installed(v),
+	}
+	if vs, err := m.Versions(ctx); err == nil {
+		if mv := vs.Get(v.Normalized); mv != nil && mv.Latest().Compare(v) > 0 {
+			info.Newer = mv.Latest().Raw
+		}
+	}
+	if !info.Installed {
+		return info, nil
+	}
+
+	sdk := m.newSDK(v)
+	info.GOROOT, info.Root, info.Shared, info.Locked = sdk.GOROOT, sdk.Root, sdk.Shared, sdk.Locked
+	info.Size = dirSize(ctx, sdk.GOROOT)
+	info.Manifest, _ = m.Manifest(v)
+	if t := m.LastUsed(v); !t.IsZero() {
+		info.LastUsed = &t
+	}
+	if v.IsVariant() {
+		info.Variant, _ = m.VariantInfo(v)
+	}
+	if v.IsTip() {
+		info.Tip, _ = m.TipInfo()
+	}
+	info.Links = m.linksOf(ctx, v)
+	return info, nil
+}
+
+// linksOf 查找 $GOBIN 中会运行版本 v 的带版本号的命令，如 go1.22.5、go1.22、gotip，
+// 名称对应的已安装版本为 v，且软链最终指向 Shim 的即是
+func (m *Manager) linksOf(ctx context.Context, v *Version) []string {
+	if len(m.opts.GOBIN) == 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(m.opts.GOBIN)
+	if err != nil {
+		return nil
+	}
+	shim, _ := filepath.EvalSymlinks(m.opts.Shim)
+	var links []string
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), exe())
+		if !strings.HasPrefix(name, "go1.") && name != "gotip" {
+			continue
+		}
+		fp := filepath.Join(m.opts.GOBIN, e.Name())
+		if !isWindows() && len(shim) > 0 {
+			if resolved, err := filepath.EvalSymlinks(fp); err != nil || resolved != shim {
+				continue
+			}
+		}
+		sdk, err := m.ResolveLink(ctx, name)
+		if err != nil || sdk.Version.Raw != v.Raw {
+			continue
+		}
+		links = append(links, name)
+	}
+	sort.Strings(links)
+	return links
+}
+
+// linkedVersion 沿着 $GOBIN 中的软链查找第一个带版本号的名称，如 go1.22、gotip
+func (m *Manager) linkedVersion(fp string) string {
+	for range 10 {
+		name := strings.TrimSuffix(filepath.Base(fp), exe())
+		if name == "gotip" || strings.HasPrefix(name, "go1.") {
+			return name
+		}
+		link, err := os.Readlink(fp)
+		if err != nil {
+			return ""
+		}
+		if !filepath.IsAbs(link) {
+			link = filepath.Join(filepath.Dir(fp), link)
+		}
+		if filepath.Dir(link) != m.opts.GOBIN {
+			return ""
+		}
+		fp = link
+	}
+	return ""
+}
+
+// dirSize 目录中的文件占用的空间，dir 为软链时统计其指向的目录
+func dirSize(ctx context.Context, dir string) int64 {
+	if target, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = target
+	}
+	var size int64
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,82 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+//go:build !windows
+
+package sdkmgr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+func TestManager_Info(t *testing.T) {
+	dir := t.TempDir()
+	indexDir := filepath.Join(dir, "index")
+	fst.NoError(t, os.MkdirAll(indexDir, 0755))
+	for _, version := range []string{"go1.21.0", "go1.22.4", "go1.22.5"} {
+		fst.NoError(t, os.WriteFile(filepath.Join(indexDir, version+".linux-amd64.tar.gz"), nil, 0644))
+	}
+	shim := filepath.Join(dir, "smart-go-dl")
+	fst.NoError(t, os.WriteFile(shim, nil, 0755))
+	m, err := New(Options{
+		SDKDir:  filepath.Join(dir, "sdk"),
+		GOBIN:   filepath.Join(dir, "bin"),
+		Shim:    shim,
+		Indexes: []Index{&Dir{Path: indexDir}},
+	})
+	fst.NoError(t, err)
+	fst.NoError(t, os.MkdirAll(m.GOBIN(), 0755))
+	ctx := context.Background()
+	fakeInstall(t, m, "go1.21.0")
+	fakeInstall(t, m, "go1.22.4")
+	fst.NoError(t, m.LinkLatest(ctx))
+
+	v := mustParseVersion(t, "go1.22.4")
+	fst.True(t, m.LastUsed(v).IsZero())
+	fst.NoError(t, m.MarkUsed(v))
+	used := m.LastUsed(v)
+	fst.False(t, used.IsZero())
+	// 一小时内重复使用时不再更新
+	fst.NoError(t, m.MarkUsed(v))
+	fst.Equal(t, used, m.LastUsed(v))
+	old := time.Now().Add(-2 * usedPrecision)
+	fp := filepath.Join(m.DataDir(), usedDir, v.Name()+m.platformSuffix())
+	fst.NoError(t, os.Chtimes(fp, old, old))
+	fst.NoError(t, m.MarkUsed(v))
+	fst.True(t, m.LastUsed(v).After(old))
+
+	info, err := m.Info(ctx, "go1.22")
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.4", info.Version)
+	fst.True(t, info.Installed)
+	fst.Equal(t, m.GOROOT(v), info.GOROOT)
+	fst.Equal(t, m.SDKDir(), info.Root)
+	fst.True(t, info.Size > int64(len("go")))
+	fst.Equal(t, "test", info.Manifest.Source)
+	fst.NotNil(t, info.LastUsed)
+	// go、go.latest 依赖 $PATH，由调用方添加
+	fst.Equal(t, []string{"go1.22", "go1.22.4"}, info.Links)
+	fst.False(t, info.Latest)
+	fst.Equal(t, "go1.22.5", info.Newer)
+
+	info, err = m.Info(ctx, "go1.21.0")
+	fst.NoError(t, err)
+	fst.Equal(t, []string{"go1.21", "go1.21.0"}, info.Links)
+	fst.Empty(t, info.Newer)
+	fst.Nil(t, info.LastUsed)
+
+	info, err = m.Info(ctx, "go1.22.5")
+	fst.NoError(t, err)
+	fst.False(t, info.Installed)
+	fst.Empty(t, info.GOROOT)
+
+	_, err = m.Info(ctx, "go1.30")
+	fst.Error(t, err)
+}
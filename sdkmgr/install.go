@@ -0,0 +1,381 @@
+// Copyright(C) 2021 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2021/12/31
+
+package sdkmgr
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsgo/cmdutil"
+
+	"github.com/fsgo/smart-go-dl/goversion"
+)
+
+// unpackedOkay SDK 完整解压后在 GOROOT 下写入的标记文件
+const unpackedOkay = ".unpacked-success"
+
+// Install 安装 go1.x 的最新版本，返回安装的 SDK
+//
+// version: 版本号，如 go1.21、go1.21.3，或者 go1.26rc 表示 go1.26 最新的预览版本( beta 或 rc ),
+// 也可以是版本约束，如 >=1.21、~1.22、stable，会安装满足约束的最新版本
+// ch: 版本渠道，如 ChannelStable 时不会安装预览版本
+//
+// gotip 和 gotip@{ref} 会从源码构建，见 InstallTip
+//
+// 若配置了 GOBIN 和 Shim，还会创建 $GOBIN/go1.x.y、$GOBIN/go1.x 等命令
+func (m *Manager) Install(ctx context.Context, version string, ch Channel) (*SDK, error) {
+	if name, ref, _ := strings.Cut(version, "@"); name == goversion.Tip {
+		return m.InstallTip(ctx, ref)
+	}
+	if v, err := ParseVersion(version); err == nil && v.IsVariant() {
+		return nil, fmt.Errorf("%s is a variant, build it with Build", version)
+	}
+	versions, err := m.Versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer m.LinkLatest(ctx)
+
+	// 如 go1.26rc，安装 go1.26 最新的预览版本
+	if name, ok := strings.CutSuffix(version, "rc"); ok && versions.Get(name) != nil {
+		if ch == ChannelStable {
+			return nil, fmt.Errorf("%q is a pre-release, conflicts with stable", version)
+		}
+		version, ch = name, ChannelPre
+	}
+
+	mv := versions.Get(version)
+
+	// 版本约束，如 >=1.21、~1.22、stable
+	if mv == nil && IsConstraint(version) {
+		var list []*Version
+		for _, v := range versions.All() {
+			if ch.Match(v) {
+				list = append(list, v)
+			}
+		}
+		v, err := m.resolve(version, list)
+		if err != nil {
+			return nil, err
+		}
+		version = v.Raw
+		// 是次要版本的最新版本时，同时更新次要版本的链接，如 go1.22
+		if mv = versions.Get(v.Normalized); mv.LatestOf(ch) == v {
+			version = v.Normalized
+		} else {
+			mv = nil
+		}
+	}
+
+	if mv == nil {
+		m.logPrint("installVV", version)
+
+		// 用于支持安装 3 位版本，如  go1.16.0、go1.16.3
+		v, err := m.installVV(ctx, version, versions, ch)
+		if err != nil {
+			return nil, fmt.Errorf("install %q failed: %w", version, err)
+		}
+		return m.newSDK(v), nil
+	}
+
+	last := mv.LatestOf(ch)
+	if last == nil {
+		return nil, fmt.Errorf("no %s version of %s found", ch, version)
+	}
+
+	m.logPrint("install", fmt.Sprintf("found %s's latest %s version is %s", version, ch, last.Raw))
+
+	if err = m.installWithVersion(ctx, last); err != nil {
+		return nil, err
+	}
+
+	goBinTo := m.BinPath(last)
+	goBinLink := m.MinorBinPath(last)
+	m.logPrint("trace", "goBinLink=", goBinLink, "goBinTo=", goBinTo)
+	if len(m.opts.Shim) == 0 || goBinLink == goBinTo {
+		return m.newSDK(last), nil
+	}
+
+	// 如已安装了 go1.26.1，再安装 go1.26rc2 时，go1.26 依然链接到 go1.26.1
+	for _, pv := range mv.PatchVersions {
+		if pv.Compare(last) > 0 && m.Installed(pv) {
+			m.logPrint("link", goBinLink, "keep linked to", pv.Raw)
+			return m.newSDK(last), nil
+		}
+	}
+
+	// create link for go bin
+	// go1.16.6 -> go1.16
+	if err = m.createLink(goBinTo, goBinLink); err != nil {
+		return nil, err
+	}
+
+	m.logger.Printf("Success. You may now run '%s'\n", version)
+	m.printPATHMessage(goBinTo)
+	return m.newSDK(last), nil
+}
+
+// installVV 安装指定的小版本
+func (m *Manager) installVV(ctx context.Context, version string, vvs Versions, ch Channel) (*Version, error) {
+	if vvs.Get(version) != nil {
+		// 不应该执行到这个逻辑
+		return nil, errors.New("now allow, bug here")
+	}
+	vu, err := ParseVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	mv := vvs.Get(vu.Normalized)
+	if mv == nil {
+		return nil, errors.New("minor version not found")
+	}
+	var installVersion *Version
+	for _, pv := range mv.PatchVersions {
+		// 如 go1.16.0 和 go1.16 都是 go1.16 的首个正式版本
+		if pv.Name() == vu.Name() {
+			installVersion = pv
+			break
+		}
+	}
+	if installVersion == nil {
+		return nil, errors.New("version not found")
+	}
+	if !ch.Match(installVersion) {
+		return nil, fmt.Errorf("%s is not a %s version", installVersion.Raw, ch)
+	}
+	return installVersion, m.installWithVersion(ctx, installVersion)
+}
+
+// installWithVersion 下载 SDK，并创建 $GOBIN/go1.x.y
+func (m *Manager) installWithVersion(ctx context.Context, ver *Version) error {
+	m.logPrint("trace", "installWithVersion", ver.String())
+	if err := m.Download(ctx, ver); err != nil {
+		return err
+	}
+
+	goBinTo := m.BinPath(ver)
+	if len(m.opts.Shim) == 0 || len(goBinTo) == 0 {
+		return nil
+	}
+
+	// smart-go-dl 可以将自己重命名为 go1.x.y，运行时会使用对应版本的 SDK
+	if err := m.createLink(m.opts.Shim, goBinTo); err != nil {
+		m.logPrint("createLink", m.opts.Shim, "->", goBinTo, ", err=", err)
+		return err
+	}
+	m.logger.Printf("Success. You may now run '%s'\n", filepath.Base(goBinTo))
+	return nil
+}
+
+func (m *Manager) printPATHMessage(goBinTo string) {
+	name := filepath.Base(goBinTo)
+	_, err := exec.LookPath(name)
+	if err == nil {
+		return
+	}
+	dir := filepath.Dir(goBinTo)
+	m.logger.Printf("%q not in $PATH", dir)
+}
+
+// Download 下载并解压指定版本的 SDK 到其 GOROOT，已完整解压过的不会重复下载
+// 会依次使用配置的打包文件来源，直到有一个成功，不会创建 $GOBIN 下的命令。
+// 当前平台没有可用的二进制打包文件时，会下载源码打包文件并使用已安装的 SDK 构建，见 MinBootstrap
+//
+// 下载和解压都在临时目录 {SDKDir}/.{version}.staging 中进行，完成后才会移动到 GOROOT，
+// 期间持有 {SDKDir}/.{version}.lock 文件锁，失败或者 ctx 取消时都会清理掉
+func (m *Manager) Download(ctx context.Context, v *Version) error {
+	if v.IsTip() {
+		return errors.New("gotip is built from source, use InstallTip")
+	}
+	if v.IsVariant() {
+		return fmt.Errorf("%s is a variant, build it with Build", v.Raw)
+	}
+	unlock := m.lockVersion(m.GOROOT(v))
+	defer unlock()
+
+	if err := os.MkdirAll(m.opts.SDKDir, 0755); err != nil {
+		return err
+	}
+	dirName := filepath.Base(m.GOROOT(v))
+	unlockFile, err := lockFile(ctx, filepath.Join(m.opts.SDKDir, "."+dirName+".lock"))
+	if err != nil {
+		return err
+	}
+	defer unlockFile()
+
+	if m.Unpacked(v) {
+		m.logPrint("download", v.Name(), "already downloaded")
+		return nil
+	}
+	if err = m.checkOwned(m.GOROOT(v)); err != nil {
+		return fmt.Errorf("%w, adopt or remove it first", err)
+	}
+
+	staging := filepath.Join(m.opts.SDKDir, "."+dirName+".staging")
+	_ = os.RemoveAll(staging)
+	defer os.RemoveAll(staging)
+	dlDir := filepath.Join(staging, "dl")
+	if err = os.MkdirAll(dlDir, 0755); err != nil {
+		return err
+	}
+
+	req := &FetchRequest{
+		Version: v,
+		GOOS:    m.Platform().GOOS,
+		GOARCH:  m.Platform().GOARCH,
+		Dir:     dlDir,
+	}
+	root := filepath.Join(staging, "go")
+	mf := &Manifest{}
+	err = m.fetch(ctx, req, func(ar *Archive) error {
+		return m.unpackTo(ctx, ar, root, mf)
+	})
+	if err != nil {
+		// 只有所有的来源都没有二进制打包文件时才使用源码构建，网络错误等直接返回
+		if ctx.Err() != nil || m.Platform() != CurrentPlatform() || !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		// 没有官方二进制打包文件的平台，如 linux/sparc64，使用源码构建
+		m.logPrint("download", "no binary archive for", v.Name(), m.Platform().String()+", try to build from source")
+		if errSrc := m.buildFromSource(ctx, v, dlDir, root, mf); errSrc != nil {
+			return fmt.Errorf("%w; build from source failed: %w", err, errSrc)
+		}
+	}
+	return m.installGOROOT(root, v, mf)
+}
+
+// unpackTo 将打包文件解压到 root，并记录其来源和 sha256 到 mf
+func (m *Manager) unpackTo(ctx context.Context, ar *Archive, root string, mf *Manifest) error {
+	_ = os.RemoveAll(root)
+	if err := m.unpackArchive(ctx, ar, root); err != nil {
+		return err
+	}
+	digest, err := fileSHA256(ctx, ar.Path)
+	if err != nil {
+		return err
+	}
+	mf.Source, mf.SHA256 = ar.fetcher, digest
+	return nil
+}
+
+// fetch 依次使用配置的打包文件来源获取打包文件，并交给 use 处理，直到有一个成功
+// 离线模式下只使用本地的来源，临时下载的打包文件在 use 之后会移动到缓存中，或者被删除
+func (m *Manager) fetch(ctx context.Context, req *FetchRequest, use func(ar *Archive) error) error {
+	v := req.Version
+	err := fmt.Errorf("no fetcher for %s", v.Raw)
+	if m.opts.Offline {
+		err = fmt.Errorf("no local archive for %s: %w", v.Raw, ErrOffline)
+	}
+	// errFailed 除了不存在以外的错误，如网络错误、打包文件不可用，所有的来源都返回不存在时才是不存在
+	var errFailed error
+	for _, f := range m.fetchers {
+		if m.opts.Offline && !isLocal(f) {
+			m.logPrint("fetch", "skip", f.Name(), "in offline mode")
+			continue
+		}
+		m.logPrint("fetch", v.Name(), "by", f.Name())
+		var ar *Archive
+		if ar, err = f.Fetch(ctx, req); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, fs.ErrNotExist) {
+				m.logPrint("fetch", f.Name(), "not found")
+			} else {
+				m.logPrint("fetch", f.Name(), "failed:", err)
+				errFailed = err
+			}
+			continue
+		}
+		ar.fetcher = f.Name()
+		err = use(ar)
+		if err == nil {
+			m.cacheArchive(ctx, req, ar)
+		}
+		if ar.Temporary {
+			_ = os.Remove(ar.Path)
+		}
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		m.logPrint("fetch", f.Name(), "unusable:", err)
+		errFailed = err
+	}
+	if errFailed != nil {
+		err = errFailed
+	}
+	if m.opts.Offline && !errors.Is(err, ErrOffline) {
+		err = fmt.Errorf("%w: %w", ErrOffline, err)
+	}
+	return err
+}
+
+// unpackArchive 将打包文件解压到 dir 目录下，成功后写入 unpackedOkay 标记文件
+func (m *Manager) unpackArchive(ctx context.Context, ar *Archive, dir string) (err error) {
+	f := ar.Path
+	info, err := os.Stat(f)
+	if err != nil {
+		m.logPrint("unpack", "error,", err)
+		return err
+	}
+	m.logPrint("unpack", f, "size=", info.Size())
+	defer func() {
+		m.logPrint("unpack", "done,", err)
+		if err != nil {
+			return
+		}
+		err = os.WriteFile(filepath.Join(dir, unpackedOkay), nil, 0644)
+	}()
+
+	if strings.HasSuffix(f, ".zip") {
+		z := &cmdutil.Zip{
+			StripComponents: ar.StripComponents,
+			UnpackNextBefore: func(*zip.File) (bool, error) {
+				return false, ctx.Err()
+			},
+		}
+		if err = z.Unpack(f, dir); err != nil {
+			return err
+		}
+		return fixExecutable(dir)
+	}
+	tr := &cmdutil.Tar{
+		StripComponents: ar.StripComponents,
+		UnpackNextBefore: func(*tar.Header) (bool, error) {
+			return false, ctx.Err()
+		},
+	}
+	return tr.Unpack(f, dir)
+}
+
+// fixExecutable 模块 zip 文件中没有文件权限，需要给 bin 和 pkg/tool 下的命令添加可执行权限
+func fixExecutable(root string) error {
+	if isWindows() {
+		return nil
+	}
+	ms, _ := filepath.Glob(filepath.Join(root, "bin", "*"))
+	tools, _ := filepath.Glob(filepath.Join(root, "pkg", "tool", "*", "*"))
+	for _, fp := range append(ms, tools...) {
+		info, err := os.Stat(fp)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if err = os.Chmod(fp, info.Mode()|0111); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+// archiveOf 生成只包含 go/bin/go 的官方格式的打包文件，不同的文件名内容也不同
+func archiveOf(t *testing.T, name string) []byte {
+	t.Helper()
+	content := []byte(name)
+	bf := &bytes.Buffer{}
+	if strings.HasSuffix(name, ".zip") {
+		zw := zip.NewWriter(bf)
+		w, err := zw.Create("go/bin/go.exe")
+		fst.NoError(t, err)
+		_, err = w.Write(content)
+		fst.NoError(t, err)
+		fst.NoError(t, zw.Close())
+		return bf.Bytes()
+	}
+	gw := gzip.NewWriter(bf)
+	tw := tar.NewWriter(gw)
+	fst.NoError(t, tw.WriteHeader(&tar.Header{Name: "go/bin/go", Mode: 0755, Size: int64(len(content))}))
+	_, err := tw.Write(content)
+	fst.NoError(t, err)
+	fst.NoError(t, tw.Close())
+	fst.NoError(t, gw.Close())
+	return bf.Bytes()
+}
+
+func TestManager_Download(t *testing.T) {
+	v, err := ParseVersion("go1.22.5")
+	fst.NoError(t, err)
+	name := v.ArchiveName(runtime.GOOS, runtime.GOARCH)
+
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dl/"+name {
+			http.NotFound(w, r)
+			return
+		}
+		hits++
+		_, _ = w.Write(archiveOf(t, name))
+	}))
+	defer ts.Close()
+
+	m, err := New(Options{
+		SDKDir:  t.TempDir(),
+		Mirrors: []string{ts.URL + "/404/", ts.URL + "/dl"},
+	})
+	fst.NoError(t, err)
+
+	ctx := context.Background()
+	fst.NoError(t, m.Download(ctx, v))
+	fst.True(t, m.Installed(v))
+	fst.True(t, m.Unpacked(v))
+	fst.Equal(t, 1, hits)
+
+	// 下载的打包文件已删除
+	_, err = os.Stat(filepath.Join(m.GOROOT(v), name))
+	fst.True(t, os.IsNotExist(err))
+
+	// 已下载过的不会重复下载
+	fst.NoError(t, m.Download(ctx, v))
+	fst.Equal(t, 1, hits)
+}
+
+func TestManager_Download_cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(make([]byte, 1024))
+		w.(http.Flusher).Flush()
+		// 收到部分内容后中断
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	m, err := New(Options{
+		SDKDir:  t.TempDir(),
+		Mirrors: []string{ts.URL},
+	})
+	fst.NoError(t, err)
+
+	v, err := ParseVersion("go1.22.5")
+	fst.NoError(t, err)
+	err = m.Download(ctx, v)
+	fst.ErrorIs(t, err, context.Canceled)
+
+	// 不会遗留下载的 .part 文件、临时目录和锁文件
+	entries, err := os.ReadDir(m.SDKDir())
+	fst.NoError(t, err)
+	fst.Empty(t, entries)
+}
+
+func TestManager_Download_offline(t *testing.T) {
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		http.NotFound(w, r)
+	}))
+	defer ts.Close()
+
+	v, err := ParseVersion("go1.22.5")
+	fst.NoError(t, err)
+	name := v.ArchiveName(runtime.GOOS, runtime.GOARCH)
+	dir := t.TempDir()
+
+	m, err := New(Options{
+		SDKDir:   t.TempDir(),
+		Offline:  true,
+		Indexes:  []Index{&FeedIndex{URL: ts.URL}},
+		Fetchers: []Fetcher{&Mirror{URLs: []string{ts.URL}}, &Dir{Path: dir}},
+	})
+	fst.NoError(t, err)
+
+	ctx := context.Background()
+	fst.ErrorIs(t, m.Refresh(ctx), ErrOffline)
+	fst.ErrorIs(t, m.Download(ctx, v), ErrOffline)
+
+	fst.NoError(t, os.WriteFile(filepath.Join(dir, name), archiveOf(t, name), 0644))
+	fst.NoError(t, m.Download(ctx, v))
+	fst.True(t, m.Installed(v))
+	fst.Equal(t, 0, hits)
+}
@@ -0,0 +1,154 @@
+// Copyright(C) 2022 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2022/11/29
+
+package sdkmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LinkLatest 创建 $GOBIN/go.latest，链接到已安装的最新正式版本，不会使用变体版本
+// 若 $GOBIN/go 不存在，会同时创建 $GOBIN/go；
+// 已安装的版本缺少 $GOBIN/go1.x.y 时也会创建，如 SharedSDKDirs 中的版本
+func (m *Manager) LinkLatest(ctx context.Context) error {
+	if len(m.opts.GOBIN) == 0 {
+		return nil
+	}
+	sdks, err := m.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range sdks {
+		if s.Version.IsTip() || len(m.opts.Shim) == 0 {
+			continue
+		}
+		if _, err = os.Lstat(m.BinPath(s.Version)); !os.IsNotExist(err) {
+			continue
+		}
+		if err = m.linkVersion(ctx, s.Version); err != nil {
+			return err
+		}
+	}
+	latest := latestVersion(sdks)
+	if latest == nil {
+		return nil
+	}
+
+	latestBinPath := filepath.Join(m.opts.GOBIN, "go.latest"+exe())
+
+	if err1 := m.createLink(m.MinorBinPath(latest), latestBinPath); err1 != nil {
+		return err1
+	}
+
+	// 若是 $GOBIN/go 不存在，则创建一个软连接
+	goPath := filepath.Join(m.opts.GOBIN, "go"+exe())
+	if _, err2 := os.Stat(goPath); os.IsNotExist(err2) {
+		_ = m.createLink(latestBinPath, goPath)
+	}
+
+	return nil
+}
+
+// latestVersion $GOBIN/go.latest 应链接到的版本：已安装的最新正式版本，没有时为最新的预览版本，
+// 不会使用 gotip 和变体版本，sdks 需要按照版本倒序排列
+func latestVersion(sdks []*SDK) *Version {
+	var latest *Version
+	for _, s := range sdks {
+		if s.Version.IsTip() || s.Version.IsVariant() {
+			continue
+		}
+		if s.Version.IsNormal() {
+			return s.Version
+		}
+		if latest == nil {
+			latest = s.Version
+		}
+	}
+	return latest
+}
+
+// linkVersion 创建 $GOBIN/go1.x.y，是次要版本（变体版本为其分组，如 go1.22-acme）中已安装的最新版本时，
+// 同时创建 $GOBIN/go1.x
+func (m *Manager) linkVersion(ctx context.Context, v *Version) error {
+	goBinTo := m.BinPath(v)
+	if len(m.opts.Shim) == 0 || len(goBinTo) == 0 {
+		return nil
+	}
+	if err := m.createLink(m.opts.Shim, goBinTo); err != nil {
+		return err
+	}
+	m.logger.Printf("Success. You may now run '%s'\n", filepath.Base(goBinTo))
+	sdks, err := m.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range sdks {
+		if s.Version.Normalized == v.Normalized && s.Version.Compare(v) > 0 {
+			return nil
+		}
+	}
+	return m.createLink(goBinTo, m.MinorBinPath(v))
+}
+
+// createLink 创建 to -> from 的软链，在 windows 下会复制文件
+// 在同一个目录下时使用相对路径
+func (m *Manager) createLink(from string, to string) error {
+	from = filepath.Clean(from)
+	to = filepath.Clean(to)
+	if from == to {
+		return nil
+	}
+	if err := os.Remove(to); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if isWindows() {
+		if err := m.copyFile(from, to); err != nil {
+			return err
+		}
+	} else {
+		target := from
+		if filepath.Dir(from) == filepath.Dir(to) {
+			target = filepath.Base(from)
+		}
+		if err := os.Symlink(target, to); err != nil {
+			return err
+		}
+	}
+	m.logPrint("link", from, "->", to, "success")
+	return nil
+}
+
+func (m *Manager) copyFile(src, dst string) error {
+	m.logPrint("trace", "copyFile", src, "->", dst)
+	si, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("os.Open(%q) %w", src, err)
+	}
+	_ = os.Remove(dst)
+	return copyRegular(src, dst, si.Mode())
+}
+
+// copyRegular 复制普通文件，dst 使用 perm 权限
+func copyRegular(src string, dst string, perm os.FileMode) error {
+	sf, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sf.Close()
+	df, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_RDWR, perm)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(df, sf); err != nil {
+		df.Close()
+		return err
+	}
+	return df.Close()
+}
@@ -0,0 +1,55 @@
+// Copyright(C) 2022 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2022/1/1
+
+package sdkmgr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const lockedName = "smart-go-dl.locked"
+
+// Lock 给指定版本添加 lock 标记文件，被 lock 的版本不会被清理
+// version 也可以是版本约束，如 ~1.22，会使用满足约束的已安装的最新版本
+func (m *Manager) Lock(ctx context.Context, version string) error {
+	sdk, err := m.resolveWritable(ctx, version)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(sdk.GOROOT, lockedName), []byte("clean locked"), 0655)
+}
+
+// Unlock 删除指定版本的 lock 标记文件
+func (m *Manager) Unlock(ctx context.Context, version string) error {
+	sdk, err := m.resolveWritable(ctx, version)
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(sdk.GOROOT, lockedName)
+	if err = os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// resolveWritable 查找满足版本号或者版本约束的、已安装的最新版本，需要在可写的 SDKDir 中
+func (m *Manager) resolveWritable(ctx context.Context, version string) (*SDK, error) {
+	sdk, err := m.Resolve(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if sdk.Shared {
+		return nil, fmt.Errorf("%s is in the read-only %s", sdk.Version.Raw, sdk.Root)
+	}
+	return sdk, nil
+}
+
+// IsLocked 指定版本是否被 lock 了
+func (m *Manager) IsLocked(v *Version) bool {
+	_, err := os.Stat(filepath.Join(m.installedGOROOT(v), lockedName))
+	return err == nil
+}
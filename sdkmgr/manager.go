@@ -0,0 +1,504 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+// Package sdkmgr 管理本机安装的多个 Go SDK：安装、删除、列出和查找
+//
+// Manager 的所有状态都来自 Options，不依赖全局变量和当前工作目录，
+// 可以嵌入到其他程序中使用，多个 Manager 也可以同时使用
+package sdkmgr
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsgo/smart-go-dl/goversion"
+)
+
+// Options 创建 Manager 的选项
+type Options struct {
+	// SDKDir 安装目录，必填
+	// 不同的 Go 版本在 SDKDir 中以子目录方式存在，如 ~/sdk/go1.22.0/
+	SDKDir string
+
+	// SharedSDKDirs 只读的共享安装目录，可选，如运维维护的 /opt/go-sdks
+	// 查找已安装的版本时（List、Resolve 等）依次查找 SDKDir 和 SharedSDKDirs，同一版本优先使用前面的，
+	// 安装、删除只会修改 SDKDir
+	SharedSDKDirs []string
+
+	// GOBIN 命令的安装目录，可选，为空时不会创建 $GOBIN/go1.x 等命令
+	GOBIN string
+
+	// DataDir 数据/缓存目录，可选，默认为 {SDKDir}/smart-go-dl
+	DataDir string
+
+	// CacheDir 下载的打包文件的缓存目录，可选，默认为 {DataDir}/cache
+	// 可以是多个用户、多个容器共享的目录，见 Manager.CacheDir
+	CacheDir string
+
+	// GoRepo Go 源码仓库地址，用于构建 gotip，可选，默认为 DefaultGoRepo
+	GoRepo string
+
+	// Platform 目标平台，可选，默认为当前平台
+	// 配置后会安装该平台的 SDK 到 {SDKDir}/{version}.{goos}-{goarch}，如 go1.22.5.linux-arm64，
+	// 用于为其他平台准备 SDK，此时 GOBIN 和 Shim 不会生效，不会创建 $GOBIN 下的命令
+	Platform Platform
+
+	// CacheSize 缓存的大小上限，单位字节，可选
+	// 为 0 时使用 DefaultCacheSize，小于 0 时不缓存
+	CacheSize int64
+
+	// Shim 链接到 $GOBIN/go1.x.y 的 smart-go-dl 程序的路径，可选
+	// 为空时不创建 $GOBIN/go1.x.y 等链接
+	Shim string
+
+	// Mirrors 下载 go 打包文件的 url 地址前缀，可选，会依次尝试
+	// 为空时使用默认值 DefaultMirrors
+	Mirrors []string
+
+	// Indexes 版本列表的来源，可选，会依次尝试，使用第一个有数据的
+	// 为空时使用 golang/dl 的 git 仓库，见 GitIndex
+	Indexes []Index
+
+	// Fetchers 打包文件的来源，可选，会依次尝试
+	// 为空时使用 Mirrors 中的下载地址，见 Mirror
+	Fetchers []Fetcher
+
+	// HTTPClient 下载使用的 client，可选
+	HTTPClient *http.Client
+
+	// InsecureSkipVerify 是否跳过证书校验，用于 git 命令和默认的 HTTPClient
+	InsecureSkipVerify bool
+
+	// Offline 离线模式，不会访问网络：不更新版本列表，只使用本地的打包文件来源，见 LocalFetcher
+	Offline bool
+
+	// GoGit 更新版本列表时是否直接使用纯 Go 实现的 git，而不是 git 命令
+	GoGit bool
+
+	// Logger 日志，可选
+	Logger *log.Logger
+
+	// Output git、wget 等子命令的输出，可选
+	Output io.Writer
+}
+
+// DefaultMirrors 默认的下载地址前缀
+var DefaultMirrors = []string{
+	"https://go.dev/dl/",
+	"https://dl-ssl.google.com/go/", // 部分不能使用 tls 的尝试这个
+	"https://dl.google.com/go/",
+	// "https://studygolang.com/dl/golang/",
+}
+
+// Manager 管理 Go SDK
+type Manager struct {
+	opts   Options
+	logger *log.Logger
+	client *http.Client
+	output io.Writer
+
+	indexes  []Index
+	fetchers []Fetcher
+
+	// locks 每个 GOROOT 一个锁，避免同时安装、删除同一个版本
+	locks sync.Map
+
+	// refreshMux 避免同时更新版本列表
+	refreshMux sync.Mutex
+}
+
+// New 创建 Manager，Options 中的目录会转换为绝对路径
+func New(opts Options) (*Manager, error) {
+	if len(opts.SDKDir) == 0 {
+		return nil, errors.New("SDKDir is required")
+	}
+	var err error
+	if opts.SDKDir, err = filepath.Abs(opts.SDKDir); err != nil {
+		return nil, err
+	}
+	for i, dir := range opts.SharedSDKDirs {
+		if opts.SharedSDKDirs[i], err = filepath.Abs(dir); err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.DataDir) == 0 {
+		opts.DataDir = filepath.Join(opts.SDKDir, "smart-go-dl")
+	}
+	if opts.DataDir, err = filepath.Abs(opts.DataDir); err != nil {
+		return nil, err
+	}
+	if len(opts.CacheDir) == 0 {
+		opts.CacheDir = filepath.Join(opts.DataDir, "cache")
+	}
+	if opts.CacheDir, err = filepath.Abs(opts.CacheDir); err != nil {
+		return nil, err
+	}
+	if len(opts.GOBIN) != 0 {
+		if opts.GOBIN, err = filepath.Abs(opts.GOBIN); err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.Shim) != 0 {
+		if opts.Shim, err = filepath.Abs(opts.Shim); err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.Mirrors) == 0 {
+		opts.Mirrors = DefaultMirrors
+	}
+	if !opts.Platform.IsZero() {
+		opts.GOBIN = ""
+		opts.Shim = ""
+	}
+	m := &Manager{
+		opts:   opts,
+		logger: opts.Logger,
+		client: opts.HTTPClient,
+		output: opts.Output,
+	}
+	if m.logger == nil {
+		m.logger = log.New(io.Discard, "", 0)
+	}
+	if m.client == nil {
+		m.client = m.defaultClient()
+	}
+	if m.output == nil {
+		m.output = io.Discard
+	}
+
+	m.indexes = opts.Indexes
+	if len(m.indexes) == 0 {
+		m.indexes = []Index{&GitIndex{}}
+	}
+	fetchers := opts.Fetchers
+	if len(fetchers) == 0 {
+		fetchers = []Fetcher{&Mirror{}}
+	}
+	// 导入的离线包和打包文件缓存总是最先使用，见 ImportBundle 和 CacheDir
+	m.fetchers = append([]Fetcher{&Dir{Path: m.BundleDir()}, &archiveCache{m: m}}, fetchers...)
+	for _, idx := range m.indexes {
+		if mi, ok := idx.(managed); ok {
+			mi.setManager(m)
+		}
+	}
+	for _, f := range m.fetchers {
+		if mf, ok := f.(managed); ok {
+			mf.setManager(m)
+		}
+	}
+	return m, nil
+}
+
+func (m *Manager) defaultClient() *http.Client {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.DialContext = (&net.Dialer{Timeout: 5 * time.Second}).DialContext
+	if m.opts.InsecureSkipVerify {
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return &http.Client{
+		Transport: tr,
+		Timeout:   10 * time.Minute,
+	}
+}
+
+// ErrOffline 离线模式下需要访问网络时返回的错误
+var ErrOffline = errors.New("offline mode, network is not allowed")
+
+// Offline 是否离线模式
+func (m *Manager) Offline() bool {
+	return m.opts.Offline
+}
+
+// SDKDir 安装目录
+func (m *Manager) SDKDir() string {
+	return m.opts.SDKDir
+}
+
+// SDKDirs 所有的安装目录，第一个为可写的 SDKDir，之后为只读的 SharedSDKDirs
+func (m *Manager) SDKDirs() []string {
+	return append([]string{m.opts.SDKDir}, m.opts.SharedSDKDirs...)
+}
+
+// GOBIN 命令的安装目录，可能为空
+func (m *Manager) GOBIN() string {
+	return m.opts.GOBIN
+}
+
+// DataDir 数据/缓存目录
+func (m *Manager) DataDir() string {
+	return m.opts.DataDir
+}
+
+func (m *Manager) logPrint(key string, msgs ...any) {
+	ks := fmt.Sprintf("%-10s : ", key)
+	var bs strings.Builder
+	bs.WriteString(ks)
+	bs.WriteString(" ")
+	for _, msg := range msgs {
+		bs.WriteString(fmt.Sprint(msg))
+		bs.WriteString(" ")
+	}
+	_ = m.logger.Output(2, bs.String())
+}
+
+// lockVersion 给指定版本加锁，返回解锁的方法
+func (m *Manager) lockVersion(name string) func() {
+	mu, _ := m.locks.LoadOrStore(name, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// Platform 安装的 SDK 的目标平台
+func (m *Manager) Platform() Platform {
+	if m.opts.Platform.IsZero() {
+		return CurrentPlatform()
+	}
+	return m.opts.Platform
+}
+
+// platformSuffix 配置了 Options.Platform 时 GOROOT 目录名的后缀，如 .linux-arm64
+func (m *Manager) platformSuffix() string {
+	if m.opts.Platform.IsZero() {
+		return ""
+	}
+	return "." + m.opts.Platform.GOOS + "-" + m.opts.Platform.GOARCH
+}
+
+// GOROOT 版本的安装目录，如 ~/sdk/go1.22.5，
+// 配置了 Options.Platform 时包含平台名称，如 ./out/go1.22.5.linux-arm64
+func (m *Manager) GOROOT(v *Version) string {
+	return filepath.Join(m.opts.SDKDir, v.Name()+m.platformSuffix())
+}
+
+// sdkRoot 已安装的版本所在的安装目录，依次查找 SDKDir 和 SharedSDKDirs，未安装时返回空
+func (m *Manager) sdkRoot(v *Version) string {
+	for _, dir := range m.SDKDirs() {
+		if m.installedIn(filepath.Join(dir, v.Name()+m.platformSuffix())) {
+			return dir
+		}
+	}
+	return ""
+}
+
+// installedGOROOT 已安装的版本的 GOROOT，可能在 SharedSDKDirs 中，未安装时返回 GOROOT
+func (m *Manager) installedGOROOT(v *Version) string {
+	if root := m.sdkRoot(v); len(root) > 0 {
+		return filepath.Join(root, v.Name()+m.platformSuffix())
+	}
+	return m.GOROOT(v)
+}
+
+// BinPath 版本的 go 命令地址，如 $GOBIN/go1.16.1，若没有配置 GOBIN 会返回空
+func (m *Manager) BinPath(v *Version) string {
+	if len(m.opts.GOBIN) == 0 {
+		return ""
+	}
+	return filepath.Join(m.opts.GOBIN, v.RawFormatted()) + exe()
+}
+
+// MinorBinPath 归一化到 2 位版本的 go 命令地址，如 $GOBIN/go1.16，若没有配置 GOBIN 会返回空
+// 在 mac、linux 下一般是一个软链，链接到当前 2 位版本的最新3位版本的 gobin
+func (m *Manager) MinorBinPath(v *Version) string {
+	if len(m.opts.GOBIN) == 0 {
+		return ""
+	}
+	return filepath.Join(m.opts.GOBIN, v.Normalized) + exe()
+}
+
+// Installed 该版本是否已经安装过了，在 SDKDir 或者 SharedSDKDirs 中
+func (m *Manager) Installed(v *Version) bool {
+	return len(m.sdkRoot(v)) > 0
+}
+
+func (m *Manager) installedIn(gr string) bool {
+	info, err := os.Stat(gr)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+	info, err = os.Stat(filepath.Join(gr, "bin", "go"+m.Platform().Exe()))
+	return err == nil && !info.IsDir()
+}
+
+// MinorInstalled 次要版本是否有任意版本已安装
+func (m *Manager) MinorInstalled(mv *MinorVersion) bool {
+	for _, pv := range mv.PatchVersions {
+		if m.Installed(pv) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unpacked 该版本的 SDK 是否已经完整的解压了
+// 原地接管的 SDK（见 Adopt）GOROOT 是软链，不会写入标记文件，已安装即可
+func (m *Manager) Unpacked(v *Version) bool {
+	gr := m.installedGOROOT(v)
+	if _, err := os.Stat(filepath.Join(gr, unpackedOkay)); err == nil {
+		return true
+	}
+	info, err := os.Lstat(gr)
+	return err == nil && info.Mode()&os.ModeSymlink != 0 && m.installedIn(gr)
+}
+
+// SDK 一个已安装的 Go SDK
+type SDK struct {
+	Version *Version
+
+	// GOROOT SDK 的目录，如 ~/sdk/go1.22.5
+	GOROOT string
+
+	// GoBin go 命令的路径，如 ~/sdk/go1.22.5/bin/go
+	GoBin string
+
+	// Locked 是否被锁定，锁定后不会被清理
+	Locked bool
+
+	// Root 所在的安装目录，如 ~/sdk、/opt/go-sdks
+	Root string
+
+	// Shared 是否在只读的 SharedSDKDirs 中，不能删除、清理
+	Shared bool
+}
+
+func (m *Manager) newSDK(v *Version) *SDK {
+	root := m.installedGOROOT(v)
+	return &SDK{
+		Version: v,
+		GOROOT:  root,
+		GoBin:   filepath.Join(root, "bin", "go"+m.Platform().Exe()),
+		Locked:  m.IsLocked(v),
+		Root:    filepath.Dir(root),
+		Shared:  filepath.Dir(root) != m.opts.SDKDir,
+	}
+}
+
+// List 已安装的所有版本，包括 SharedSDKDirs 中的，按照版本倒序排列
+func (m *Manager) List(ctx context.Context) ([]*SDK, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var result []*SDK
+	seen := make(map[string]bool)
+	for _, root := range m.SDKDirs() {
+		ms, err := filepath.Glob(filepath.Join(root, "go*"))
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range ms {
+			name, ok := strings.CutSuffix(filepath.Base(dir), m.platformSuffix())
+			if !ok {
+				continue
+			}
+			v, err := ParseVersion(name)
+			if err != nil || seen[v.Raw] || !m.Installed(v) {
+				continue
+			}
+			seen[v.Raw] = true
+			result = append(result, m.newSDK(v))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version.Compare(result[j].Version) > 0
+	})
+	return result, nil
+}
+
+// Resolve 查找满足版本号或者版本约束的、已安装的最新版本
+//
+// version: 如 go1.22（go1.22 已安装的最新版本）、go1.22.5、gotip、>=1.21、stable，
+// 发布版本号只会匹配该版本，如 go1.20 为 go1.20（即 go1.20.0），不是 go1.20 已安装的最新版本，见 ResolveLink
+func (m *Manager) Resolve(ctx context.Context, version string) (*SDK, error) {
+	// 发布版本号如 go1.20、go1.22.5、go1.26rc1、gotip 直接检查安装目录，不需要扫描所有已安装的版本
+	if v, err := ParseVersion(version); err == nil && v.Raw == version && !v.IsLanguage() {
+		if !m.Installed(v) {
+			return nil, fmt.Errorf("version %q is not installed", version)
+		}
+		return m.newSDK(v), nil
+	}
+	sdks, err := m.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*Version, 0, len(sdks))
+	for _, s := range sdks {
+		list = append(list, s.Version)
+	}
+	v, err := m.resolve(version, list)
+	if err != nil {
+		return nil, err
+	}
+	return m.newSDK(v), nil
+}
+
+// ResolveLink 查找 $GOBIN 中的命令 name 运行的已安装版本，如 go1.22.5、gotip，
+// 次要版本的命令如 go1.20、go1.22 为该次要版本已安装的最新版本
+func (m *Manager) ResolveLink(ctx context.Context, name string) (*SDK, error) {
+	if v, err := ParseVersion(name); err == nil && v.Upstream().Raw == v.Lang() {
+		// 如 go1.20 为 ~1.20，go1.20-acme 为 ~1.20-acme
+		name = "~" + strings.TrimPrefix(v.Raw, "go")
+	}
+	return m.Resolve(ctx, name)
+}
+
+// ResolveRelease 从版本列表中查找满足版本号或者版本约束的最新版本，不要求已安装
+// 版本列表需要先使用 Refresh 更新
+func (m *Manager) ResolveRelease(ctx context.Context, version string) (*Version, error) {
+	versions, err := m.Versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.resolve(version, versions.All())
+}
+
+// resolve 从版本列表中找出满足版本号或者版本约束的最新版本
+func (m *Manager) resolve(version string, list []*Version) (*Version, error) {
+	if version == goversion.Tip {
+		for _, v := range list {
+			if v.IsTip() {
+				return v, nil
+			}
+		}
+		return nil, fmt.Errorf("version %q not found", version)
+	}
+	c, err := goversion.ParseConstraint(version)
+	if err != nil {
+		return nil, err
+	}
+	gvs := make([]*goversion.Version, 0, len(list))
+	all := make(map[*goversion.Version]*Version, len(list))
+	for _, v := range list {
+		gvs = append(gvs, &v.Version)
+		all[&v.Version] = v
+	}
+	if gv := c.Resolve(gvs); gv != nil {
+		v := all[gv]
+		m.logPrint("resolve", fmt.Sprintf("%q -> %s", version, v.Raw))
+		return v, nil
+	}
+	return nil, fmt.Errorf("no version matches %q", version)
+}
+
+func exe() string {
+	if isWindows() {
+		return ".exe"
+	}
+	return ""
+}
+
+func isWindows() bool {
+	return runtime.GOOS == "windows"
+}
@@ -0,0 +1,127 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+// fakeInstall 在 SDKDir 下创建一个只有 bin/go 和安装信息的 SDK
+func fakeInstall(t *testing.T, m *Manager, version string) {
+	t.Helper()
+	v, err := ParseVersion(version)
+	fst.NoError(t, err)
+	bin := filepath.Join(m.GOROOT(v), "bin")
+	fst.NoError(t, os.MkdirAll(bin, 0755))
+	fst.NoError(t, os.WriteFile(filepath.Join(bin, "go"+exe()), []byte("go"), 0755))
+	fst.NoError(t, writeManifest(m.GOROOT(v), &Manifest{Version: v.Raw, Source: "test"}))
+}
+
+func TestManager(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	m, err := New(Options{
+		SDKDir: filepath.Join(dir, "sdk"),
+		GOBIN:  filepath.Join(dir, "bin"),
+	})
+	fst.NoError(t, err)
+	fst.Equal(t, filepath.Join(dir, "sdk", "smart-go-dl"), m.DataDir())
+
+	for _, v := range []string{"go1.20", "go1.21.0", "go1.22.1", "go1.22.5", "go1.26rc1"} {
+		fakeInstall(t, m, v)
+	}
+	// 没有 bin/go 的不是已安装的版本
+	fst.NoError(t, os.MkdirAll(filepath.Join(m.SDKDir(), "go1.23.0"), 0755))
+
+	sdks, err := m.List(ctx)
+	fst.NoError(t, err)
+	var names []string
+	for _, s := range sdks {
+		names = append(names, s.Version.Raw)
+	}
+	fst.Equal(t, []string{"go1.26rc1", "go1.22.5", "go1.22.1", "go1.21.0", "go1.20"}, names)
+
+	sdk, err := m.Resolve(ctx, "go1.22")
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.5", sdk.Version.Raw)
+	fst.Equal(t, filepath.Join(m.SDKDir(), "go1.22.5"), sdk.GOROOT)
+
+	sdk, err = m.Resolve(ctx, "go1.20.0")
+	fst.NoError(t, err)
+	fst.Equal(t, filepath.Join(m.SDKDir(), "go1.20"), sdk.GOROOT)
+
+	sdk, err = m.Resolve(ctx, "stable")
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.5", sdk.Version.Raw)
+
+	_, err = m.Resolve(ctx, "go1.23")
+	fst.Error(t, err)
+
+	fst.NoError(t, m.Lock(ctx, "go1.22.1"))
+	sdk, err = m.Resolve(ctx, "go1.22.1")
+	fst.NoError(t, err)
+	fst.True(t, sdk.Locked)
+	fst.NoError(t, m.Unlock(ctx, "go1.22.1"))
+	fst.False(t, m.IsLocked(sdk.Version))
+
+	fst.Error(t, m.Remove(ctx, "go1.22", RemoveOptions{}))
+	fst.NoError(t, m.Remove(ctx, "go1.22.1", RemoveOptions{}))
+	fst.Error(t, m.Remove(ctx, "go1.22.1", RemoveOptions{}))
+	_, err = os.Stat(filepath.Join(m.SDKDir(), "go1.22.1"))
+	fst.True(t, os.IsNotExist(err))
+}
+
+func TestManager_sharedSDKDirs(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	shared, err := New(Options{SDKDir: filepath.Join(dir, "opt", "go-sdks")})
+	fst.NoError(t, err)
+	for _, v := range []string{"go1.21.0", "go1.22.5"} {
+		fakeInstall(t, shared, v)
+	}
+	m, err := New(Options{
+		SDKDir:        filepath.Join(dir, "sdk"),
+		SharedSDKDirs: []string{shared.SDKDir()},
+		GOBIN:         filepath.Join(dir, "bin"),
+	})
+	fst.NoError(t, err)
+	fst.Equal(t, []string{m.SDKDir(), shared.SDKDir()}, m.SDKDirs())
+	for _, v := range []string{"go1.22.1", "go1.22.5"} {
+		fakeInstall(t, m, v)
+	}
+
+	sdks, err := m.List(ctx)
+	fst.NoError(t, err)
+	got := make(map[string]*SDK)
+	for _, s := range sdks {
+		got[s.Version.Raw] = s
+	}
+	fst.Equal(t, 3, len(sdks))
+	// 同一版本优先使用可写的 SDKDir 中的
+	fst.Equal(t, m.SDKDir(), got["go1.22.5"].Root)
+	fst.False(t, got["go1.22.5"].Shared)
+	fst.Equal(t, shared.SDKDir(), got["go1.21.0"].Root)
+	fst.True(t, got["go1.21.0"].Shared)
+
+	sdk, err := m.Resolve(ctx, "go1.21")
+	fst.NoError(t, err)
+	fst.Equal(t, filepath.Join(shared.SDKDir(), "go1.21.0"), sdk.GOROOT)
+	fst.True(t, m.Installed(sdk.Version))
+
+	// 只读的安装目录中的版本不能删除、锁定
+	fst.Error(t, m.Remove(ctx, "go1.21.0", RemoveOptions{}))
+	fst.Error(t, m.Lock(ctx, "go1.21.0"))
+	fst.NoError(t, m.Remove(ctx, "go1.22.5", RemoveOptions{}))
+	sdk, err = m.Resolve(ctx, "go1.22.5")
+	fst.NoError(t, err)
+	fst.True(t, sdk.Shared)
+	_, err = os.Stat(filepath.Join(sdk.GOROOT, "bin", "go"+exe()))
+	fst.NoError(t, err)
+}
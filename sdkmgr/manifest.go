@@ -0,0 +1,152 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"debug/buildinfo"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+)
+
+// manifestFile 安装信息，在 GOROOT 下，有此文件的目录才是由 smart-go-dl 安装的，可以删除
+const manifestFile = ".smart-go-dl.json"
+
+// ErrNotOwned 目录不是由 smart-go-dl 安装的，没有安装信息文件，不会删除、覆盖
+var ErrNotOwned = errors.New("not installed by smart-go-dl")
+
+// Manifest 安装信息，每次安装时写入 GOROOT 下的 .smart-go-dl.json
+type Manifest struct {
+	// Version 版本，如 go1.22.5
+	Version string `json:"version"`
+
+	// Source 来源，如打包文件来源的名称、打包文件的路径、gotip 的仓库和提交
+	Source string `json:"source"`
+
+	// SHA256 打包文件的 sha256，从打包文件安装时才有
+	SHA256 string `json:"sha256,omitempty"`
+
+	// Installed 安装的时间
+	Installed time.Time `json:"installed"`
+
+	// Tool 安装时使用的 smart-go-dl 的版本，如 github.com/fsgo/smart-go-dl@v0.1.19
+	Tool string `json:"tool"`
+}
+
+// Manifest 已安装的版本的安装信息
+func (m *Manager) Manifest(v *Version) (*Manifest, error) {
+	return readManifest(m.installedGOROOT(v))
+}
+
+func readManifest(gr string) (*Manifest, error) {
+	bf, err := os.ReadFile(filepath.Join(gr, manifestFile))
+	if err != nil {
+		return nil, err
+	}
+	mf := &Manifest{}
+	if err = json.Unmarshal(bf, mf); err != nil {
+		return nil, err
+	}
+	return mf, nil
+}
+
+func writeManifest(gr string, mf *Manifest) error {
+	mf.Installed = time.Now()
+	mf.Tool = toolVersion()
+	bf, err := json.MarshalIndent(mf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(gr, manifestFile), bf, 0644)
+}
+
+// toolPath smart-go-dl 的模块路径
+const toolPath = "github.com/fsgo/smart-go-dl"
+
+// toolVersion 当前 smart-go-dl 的模块路径和版本
+func toolVersion() string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return toolPath
+	}
+	mod := &bi.Main
+	for _, dep := range bi.Deps {
+		if dep.Path == toolPath {
+			mod = dep
+		}
+	}
+	return mod.Path + "@" + mod.Version
+}
+
+// checkOwned 检查 gr 是否可以删除：不存在、是软链（原地接管的，只会删除软链），或者有安装信息文件。
+// gotip、变体版本有各自的信息文件，也可以删除；手动放到 SDKDir 中的（如自己构建的 Go）都没有。
+// 之前版本安装的只有 unpackedOkay 标记文件，golang.org/dl 也会写入，所以还需要满足 legacyOwned
+func (m *Manager) checkOwned(gr string) error {
+	info, err := os.Lstat(gr)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return nil
+	}
+	for _, name := range []string{manifestFile, tipInfoFile, variantInfoFile} {
+		if _, err = os.Stat(filepath.Join(gr, name)); err == nil {
+			return nil
+		}
+	}
+	if fileExists(filepath.Join(gr, unpackedOkay)) && m.legacyOwned(gr) {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", gr, ErrNotOwned)
+}
+
+// legacyOwned 之前版本安装的 gr 在 $GOBIN 中的同名命令（如 go1.22.5）是否为 smart-go-dl，
+// 即链接到 Shim，或者是 smart-go-dl 构建的程序（windows 下是复制的文件）。
+// golang.org/dl 安装的命令是它自己的程序
+func (m *Manager) legacyOwned(gr string) bool {
+	if len(m.opts.GOBIN) == 0 {
+		return false
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Join(m.opts.GOBIN, filepath.Base(gr)+exe()))
+	if err != nil {
+		return false
+	}
+	if len(m.opts.Shim) != 0 {
+		if shim, err := filepath.EvalSymlinks(m.opts.Shim); err == nil && shim == resolved {
+			return true
+		}
+	}
+	bi, err := buildinfo.ReadFile(resolved)
+	return err == nil && bi.Main.Path == toolPath
+}
+
+// installGOROOT 写入安装信息和标记文件后，将 root 移动为版本 v 的 GOROOT，
+// 已存在的 GOROOT 不是由 smart-go-dl 安装的时候会返回 ErrNotOwned
+func (m *Manager) installGOROOT(root string, v *Version, mf *Manifest) error {
+	gr := m.GOROOT(v)
+	if err := m.checkOwned(gr); err != nil {
+		return err
+	}
+	mf.Version = v.Raw
+	if err := writeManifest(root, mf); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(root, unpackedOkay), nil, 0644); err != nil {
+		return err
+	}
+	// 之前未完整安装的目录，没有 unpackedOkay 标记文件
+	if err := os.RemoveAll(gr); err != nil {
+		return err
+	}
+	m.logPrint("install", root, "->", gr)
+	return os.Rename(root, gr)
+}
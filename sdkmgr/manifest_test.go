@@ -0,0 +1,128 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestManager_Manifest(t *testing.T) {
+	dir := t.TempDir()
+	v := mustParseVersion(t, "go1.22.5")
+	name := v.ArchiveName(runtime.GOOS, runtime.GOARCH)
+	archives := filepath.Join(dir, "archives")
+	fst.NoError(t, os.MkdirAll(archives, 0755))
+	fst.NoError(t, os.WriteFile(filepath.Join(archives, name), archiveOf(t, name), 0644))
+
+	m, err := New(Options{
+		SDKDir:   filepath.Join(dir, "sdk"),
+		Indexes:  []Index{&Dir{Path: archives}},
+		Fetchers: []Fetcher{&Dir{Path: archives}},
+		Offline:  true,
+	})
+	fst.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("download", func(t *testing.T) {
+		fst.NoError(t, m.Download(ctx, v))
+		mf, err := m.Manifest(v)
+		fst.NoError(t, err)
+		fst.Equal(t, "go1.22.5", mf.Version)
+		fst.NotEmpty(t, mf.Source)
+		digest, err := fileSHA256(ctx, filepath.Join(archives, name))
+		fst.NoError(t, err)
+		fst.Equal(t, digest, mf.SHA256)
+		fst.NotEmpty(t, mf.Tool)
+		fst.False(t, mf.Installed.IsZero())
+	})
+
+	// 手动放到 SDKDir 中的，如自己构建的 Go，没有安装信息和标记文件
+	handBuilt := mustParseVersion(t, "go1.21.3")
+	gr := m.GOROOT(handBuilt)
+	fst.NoError(t, os.MkdirAll(filepath.Join(gr, "bin"), 0755))
+	fst.NoError(t, os.WriteFile(filepath.Join(gr, "bin", "go"+exe()), nil, 0755))
+
+	t.Run("not owned", func(t *testing.T) {
+		_, err := m.Manifest(handBuilt)
+		fst.True(t, os.IsNotExist(err))
+
+		err = m.Remove(ctx, "go1.21.3", RemoveOptions{})
+		fst.ErrorIs(t, err, ErrNotOwned)
+		fst.ErrorContains(t, err, "use force")
+		fst.True(t, m.Installed(handBuilt))
+
+		// 不会被安装覆盖
+		err = m.Download(ctx, handBuilt)
+		fst.ErrorIs(t, err, ErrNotOwned)
+		fst.ErrorContains(t, err, "adopt or remove it first")
+		fst.True(t, m.Installed(handBuilt))
+	})
+
+	t.Run("force", func(t *testing.T) {
+		fst.NoError(t, m.Remove(ctx, "go1.21.3", RemoveOptions{Force: true}))
+		fst.False(t, m.Installed(handBuilt))
+		fst.NoError(t, m.Remove(ctx, "go1.22.5", RemoveOptions{}))
+		fst.False(t, m.Installed(v))
+	})
+}
+
+func TestManager_legacy(t *testing.T) {
+	if isWindows() {
+		t.Skip("links are copied files on windows")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	shim := filepath.Join(dir, "smart-go-dl")
+	fst.NoError(t, os.WriteFile(shim, nil, 0755))
+	m, err := New(Options{
+		SDKDir: filepath.Join(dir, "sdk"),
+		GOBIN:  filepath.Join(dir, "bin"),
+		Shim:   shim,
+	})
+	fst.NoError(t, err)
+	fst.NoError(t, os.MkdirAll(m.GOBIN(), 0755))
+
+	// 之前版本安装的目录结构：只有 unpackedOkay 标记文件，没有安装信息，$GOBIN/go1.x.y 链接到 smart-go-dl
+	legacy := func(version string) *Version {
+		v := mustParseVersion(t, version)
+		gr := m.GOROOT(v)
+		fst.NoError(t, os.MkdirAll(filepath.Join(gr, "bin"), 0755))
+		fst.NoError(t, os.WriteFile(filepath.Join(gr, "bin", "go"+exe()), nil, 0755))
+		fst.NoError(t, os.WriteFile(filepath.Join(gr, unpackedOkay), nil, 0644))
+		fst.NoError(t, m.createLink(shim, m.BinPath(v)))
+		return v
+	}
+	v1, v2, v3 := legacy("go1.22.1"), legacy("go1.22.3"), legacy("go1.22.5")
+
+	fst.NoError(t, m.Clean(ctx, "go1.22", RemoveOptions{}))
+	fst.False(t, m.Installed(v1))
+	fst.False(t, m.Installed(v2))
+	fst.True(t, m.Installed(v3))
+
+	fst.NoError(t, m.Remove(ctx, "go1.22.5", RemoveOptions{}))
+	fst.False(t, m.Installed(v3))
+
+	// golang.org/dl 安装的也有 unpackedOkay 标记文件，但 $GOBIN/go1.x.y 是它自己的程序
+	dl := func(version string) *Version {
+		v := legacy(version)
+		fst.NoError(t, os.Remove(m.BinPath(v)))
+		fst.NoError(t, os.WriteFile(m.BinPath(v), []byte("golang.org/dl"), 0755))
+		return v
+	}
+	v4, v5 := dl("go1.21.1"), dl("go1.21.3")
+	fst.NoError(t, m.Clean(ctx, "go1.21", RemoveOptions{}))
+	fst.True(t, m.Installed(v4))
+	err = m.Remove(ctx, "go1.21.3", RemoveOptions{})
+	fst.ErrorIs(t, err, ErrNotOwned)
+	fst.True(t, m.Installed(v5))
+	fst.NoError(t, m.Remove(ctx, "go1.21.3", RemoveOptions{Force: true}))
+	fst.False(t, m.Installed(v5))
+}
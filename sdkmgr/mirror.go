@@ -0,0 +1,144 @@
+// Copyright(C) 2021 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2021/12/31
+
+package sdkmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var _ Fetcher = (*Mirror)(nil)
+
+// Mirror 从官方下载地址或者其镜像下载打包文件，会依次尝试每个地址
+type Mirror struct {
+	// URLs 下载地址前缀，可选，如 https://dl.google.com/go/
+	// 为空时使用 Options.Mirrors
+	URLs []string
+
+	m *Manager
+}
+
+func (mr *Mirror) setManager(m *Manager) {
+	mr.m = m
+}
+
+// Name 名称
+func (mr *Mirror) Name() string {
+	return "mirror:" + strings.Join(mr.urls(), ",")
+}
+
+func (mr *Mirror) urls() []string {
+	if len(mr.URLs) == 0 && mr.m != nil {
+		return mr.m.opts.Mirrors
+	}
+	return mr.URLs
+}
+
+// Fetch 依次从每个地址下载打包文件
+func (mr *Mirror) Fetch(ctx context.Context, req *FetchRequest) (*Archive, error) {
+	name := req.ArchiveName()
+	out := filepath.Join(req.Dir, name)
+	err := fmt.Errorf("no mirror for %s", name)
+	// errFailed 除了不存在以外的错误，如网络错误，所有的镜像都返回不存在时才是不存在
+	var errFailed error
+	for _, p := range mr.urls() {
+		p = strings.TrimSpace(p)
+		if len(p) == 0 {
+			continue
+		}
+		u := strings.TrimSuffix(p, "/") + "/" + name
+		if err = mr.m.wget(ctx, u, out); err == nil {
+			return &Archive{Path: out, StripComponents: 1, Temporary: true}, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			errFailed = err
+		}
+	}
+	if errFailed != nil {
+		return nil, errFailed
+	}
+	return nil, err
+}
+
+// wget 下载文件，先使用 HTTPClient 下载，失败后再尝试使用 wget 命令
+func (m *Manager) wget(ctx context.Context, url string, to string) error {
+	m.logPrint("download", "from", url, "to", to)
+	err1 := m.httpGet(ctx, url, to)
+	if err1 == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if errors.Is(err1, fs.ErrNotExist) {
+		// 服务端明确返回了不存在，不需要再重试
+		return err1
+	}
+	m.logPrint("http-get", "failed:", err1, ", will retry")
+
+	var args []string
+	if m.opts.InsecureSkipVerify {
+		args = append(args, "--no-check-certificate")
+	}
+	args = append(args, "--connect-timeout=5", "--tries=1", "-O", to)
+	args = append(args, url)
+	cmd1 := exec.CommandContext(ctx, "wget", args...)
+	setCancel(cmd1)
+	m.logPrint("exec", cmd1.String())
+	cmd1.Stderr = m.output
+	cmd1.Stdout = m.output
+	if err := cmd1.Run(); err != nil {
+		_ = os.Remove(to)
+		return fmt.Errorf("%w; wget: %w", err1, err)
+	}
+	return nil
+}
+
+// httpGet 使用 HTTPClient 下载文件，先写入 {to}.part，成功后再重命名为 to
+func (m *Manager) httpGet(ctx context.Context, url string, to string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusGone:
+		return fmt.Errorf("GET %s: %q: %w", url, resp.Status, fs.ErrNotExist)
+	default:
+		return fmt.Errorf("GET %s: unexpected status %q", url, resp.Status)
+	}
+	part := to + ".part"
+	f, err := os.Create(part)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(part)
+	n, err := io.Copy(f, resp.Body)
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	if err != nil {
+		return err
+	}
+	m.logPrint("download", to, "size=", n)
+	return os.Rename(part, to)
+}
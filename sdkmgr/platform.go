@@ -0,0 +1,146 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Platform 目标平台，如 linux/amd64
+type Platform struct {
+	GOOS   string
+	GOARCH string
+}
+
+// CurrentPlatform 当前程序运行的平台
+func CurrentPlatform() Platform {
+	return Platform{GOOS: runtime.GOOS, GOARCH: runtime.GOARCH}
+}
+
+// Platforms 有官方二进制打包文件的平台
+// 打包文件名中的架构名称见 goversion.Version.ArchiveName，如 linux/arm 为 armv6l
+var Platforms = []Platform{
+	{"aix", "ppc64"},
+	{"darwin", "amd64"},
+	{"darwin", "arm64"},
+	{"dragonfly", "amd64"},
+	{"freebsd", "386"},
+	{"freebsd", "amd64"},
+	{"freebsd", "arm"},
+	{"freebsd", "arm64"},
+	{"freebsd", "riscv64"},
+	{"illumos", "amd64"},
+	{"linux", "386"},
+	{"linux", "amd64"},
+	{"linux", "arm"},
+	{"linux", "arm64"},
+	{"linux", "loong64"},
+	{"linux", "mips"},
+	{"linux", "mipsle"},
+	{"linux", "mips64"},
+	{"linux", "mips64le"},
+	{"linux", "ppc64"},
+	{"linux", "ppc64le"},
+	{"linux", "riscv64"},
+	{"linux", "s390x"},
+	{"netbsd", "386"},
+	{"netbsd", "amd64"},
+	{"netbsd", "arm"},
+	{"netbsd", "arm64"},
+	{"openbsd", "386"},
+	{"openbsd", "amd64"},
+	{"openbsd", "arm"},
+	{"openbsd", "arm64"},
+	{"openbsd", "ppc64"},
+	{"openbsd", "riscv64"},
+	{"plan9", "386"},
+	{"plan9", "amd64"},
+	{"plan9", "arm"},
+	{"solaris", "amd64"},
+	{"windows", "386"},
+	{"windows", "amd64"},
+	{"windows", "arm"},
+	{"windows", "arm64"},
+}
+
+// osAliases 常见的系统名称，如 uname -s 的输出
+var osAliases = map[string]string{
+	"macos": "darwin",
+	"mac":   "darwin",
+	"osx":   "darwin",
+	"win":   "windows",
+	"sunos": "solaris",
+}
+
+// archAliases 常见的 CPU 架构名称，如 uname -m、dpkg、rpm 和官方打包文件名中的名称
+var archAliases = map[string]string{
+	"x86_64":      "amd64",
+	"x64":         "amd64",
+	"i386":        "386",
+	"i686":        "386",
+	"x86":         "386",
+	"aarch64":     "arm64",
+	"armv8":       "arm64",
+	"armv6l":      "arm",
+	"armv7l":      "arm",
+	"armhf":       "arm",
+	"armel":       "arm",
+	"loongarch64": "loong64",
+	"ppc64el":     "ppc64le",
+	"powerpc64le": "ppc64le",
+	"riscv":       "riscv64",
+	"mipsel":      "mipsle",
+	"mips64el":    "mips64le",
+}
+
+// ParsePlatform 解析平台，格式如 linux/amd64，也支持常见的别名，如 linux/aarch64、macos/x86_64
+// 只支持有官方二进制打包文件的平台，见 Platforms
+func ParsePlatform(str string) (Platform, error) {
+	goos, goarch, ok := strings.Cut(strings.ToLower(strings.TrimSpace(str)), "/")
+	if !ok || len(goos) == 0 || len(goarch) == 0 || strings.Contains(goarch, "/") {
+		return Platform{}, fmt.Errorf("invalid platform %q, expect format like linux/amd64", str)
+	}
+	if name, ok := osAliases[goos]; ok {
+		goos = name
+	}
+	if name, ok := archAliases[goarch]; ok {
+		goarch = name
+	}
+	p := Platform{GOOS: goos, GOARCH: goarch}
+	if !p.Known() {
+		return Platform{}, fmt.Errorf("platform %q has no official binary release", str)
+	}
+	return p, nil
+}
+
+// Known 是否有官方二进制打包文件
+func (p Platform) Known() bool {
+	for _, item := range Platforms {
+		if item == p {
+			return true
+		}
+	}
+	return false
+}
+
+// IsZero 是否为空
+func (p Platform) IsZero() bool {
+	return len(p.GOOS) == 0 && len(p.GOARCH) == 0
+}
+
+// String 如 linux/amd64
+func (p Platform) String() string {
+	return p.GOOS + "/" + p.GOARCH
+}
+
+// Exe 可执行文件的后缀，windows 下为 .exe
+func (p Platform) Exe() string {
+	if p.GOOS == "windows" {
+		return ".exe"
+	}
+	return ""
+}
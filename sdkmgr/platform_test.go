@@ -0,0 +1,103 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestParsePlatform(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    Platform
+		wantErr bool
+	}{
+		{str: "linux/amd64", want: Platform{"linux", "amd64"}},
+		{str: " Linux/x86_64 ", want: Platform{"linux", "amd64"}},
+		{str: "linux/aarch64", want: Platform{"linux", "arm64"}},
+		{str: "linux/armv6l", want: Platform{"linux", "arm"}},
+		{str: "linux/armv7l", want: Platform{"linux", "arm"}},
+		{str: "linux/i686", want: Platform{"linux", "386"}},
+		{str: "linux/loongarch64", want: Platform{"linux", "loong64"}},
+		{str: "linux/ppc64el", want: Platform{"linux", "ppc64le"}},
+		{str: "linux/riscv64", want: Platform{"linux", "riscv64"}},
+		{str: "linux/s390x", want: Platform{"linux", "s390x"}},
+		{str: "macos/arm64", want: Platform{"darwin", "arm64"}},
+		{str: "windows/386", want: Platform{"windows", "386"}},
+		{str: "linux", wantErr: true},
+		{str: "linux/", wantErr: true},
+		{str: "linux/amd64/v3", wantErr: true},
+		{str: "darwin/386", wantErr: true},
+		{str: "js/wasm", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			got, err := ParsePlatform(tt.str)
+			if tt.wantErr {
+				fst.Error(t, err)
+				return
+			}
+			fst.NoError(t, err)
+			fst.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_Platform(t *testing.T) {
+	var requested []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := path.Base(r.URL.Path)
+		requested = append(requested, name)
+		_, _ = w.Write(archiveOf(t, name))
+	}))
+	defer ts.Close()
+
+	sdkDir := t.TempDir()
+	gobin := t.TempDir()
+	indexDir := t.TempDir()
+	fst.NoError(t, os.WriteFile(filepath.Join(indexDir, "go1.22.5.linux-amd64.tar.gz"), nil, 0644))
+
+	ctx := context.Background()
+	for _, p := range []Platform{{"linux", "arm"}, {"windows", "arm64"}} {
+		m, err := New(Options{
+			SDKDir:   sdkDir,
+			GOBIN:    gobin,
+			Shim:     os.Args[0],
+			Platform: p,
+			Mirrors:  []string{ts.URL},
+			Indexes:  []Index{&Dir{Path: indexDir}},
+		})
+		fst.NoError(t, err)
+		sdk, err := m.Install(ctx, "go1.22", ChannelAny)
+		fst.NoError(t, err)
+		fst.Equal(t, filepath.Join(sdkDir, "go1.22.5."+p.GOOS+"-"+p.GOARCH), sdk.GOROOT)
+		fst.True(t, m.Installed(sdk.Version))
+
+		sdks, err := m.List(ctx)
+		fst.NoError(t, err)
+		fst.Equal(t, 1, len(sdks))
+	}
+	fst.Equal(t, []string{"go1.22.5.linux-armv6l.tar.gz", "go1.22.5.windows-arm64.zip"}, requested)
+
+	// 不会创建 $GOBIN 下的命令
+	entries, err := os.ReadDir(gobin)
+	fst.NoError(t, err)
+	fst.Empty(t, entries)
+
+	// 其他平台的 SDK 不是当前平台已安装的版本
+	m, err := New(Options{SDKDir: sdkDir})
+	fst.NoError(t, err)
+	sdks, err := m.List(ctx)
+	fst.NoError(t, err)
+	fst.Empty(t, sdks)
+}
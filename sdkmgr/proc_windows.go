@@ -0,0 +1,28 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+//go:build windows
+
+package sdkmgr
+
+import (
+	"os"
+	"os/exec"
+	"time"
+)
+
+// setCancel context 取消时结束子命令
+func setCancel(cmd *exec.Cmd) {
+	cmd.WaitDelay = 5 * time.Second
+}
+
+// processAlive 进程是否还在运行
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = p.Release()
+	return true
+}
@@ -0,0 +1,81 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PinFile 其他工具（如 goenv、asdf、setup-go）在项目中指定 go 版本的文件，内容如 go1.22.5、1.22.5、~1.22，
+// 只用于 which、info 的说明，不影响 go 命令使用的版本
+const PinFile = ".go-version"
+
+// ProjectVersion 项目中指定的 go 版本，见 FindToolchain、FindPin
+type ProjectVersion struct {
+	// File 指定版本的文件，如 /home/work/app/go.mod、/home/work/app/.go-version
+	File string `json:"file"`
+
+	// Version 版本号或者版本约束，如 go1.22.5、~1.22
+	Version string `json:"version"`
+}
+
+// FindToolchain 从 dir 开始逐级向上查找 go.work、go.mod，返回其中的 toolchain 指令，如 go1.22.5
+// 最先找到的文件中没有 toolchain 指令（或者为 toolchain default）时返回 nil
+func FindToolchain(dir string) *ProjectVersion {
+	for ; ; dir = filepath.Dir(dir) {
+		for _, name := range []string{"go.work", "go.mod"} {
+			fp := filepath.Join(dir, name)
+			if _, err := os.Stat(fp); err != nil {
+				continue
+			}
+			version := readToolchain(fp)
+			if len(version) == 0 || version == "default" {
+				return nil
+			}
+			return &ProjectVersion{File: fp, Version: version}
+		}
+		if filepath.Dir(dir) == dir {
+			return nil
+		}
+	}
+}
+
+// readToolchain 读取 go.mod、go.work 中的 toolchain 指令的值，如 "toolchain go1.22.5 // 注释" 为 go1.22.5
+func readToolchain(fp string) string {
+	f, err := os.Open(fp)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) >= 2 && fields[0] == "toolchain" {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// FindPin 从 dir 开始逐级向上查找 PinFile，没有或者内容为空时返回 nil
+func FindPin(dir string) *ProjectVersion {
+	for ; ; dir = filepath.Dir(dir) {
+		fp := filepath.Join(dir, PinFile)
+		if bf, err := os.ReadFile(fp); err == nil {
+			// 只使用第一行，如 "go1.22.5\n"
+			line, _, _ := strings.Cut(string(bf), "\n")
+			if version := strings.TrimSpace(line); len(version) > 0 {
+				return &ProjectVersion{File: fp, Version: version}
+			}
+			return nil
+		}
+		if filepath.Dir(dir) == dir {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestFindToolchain(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "a", "b")
+	fst.NoError(t, os.MkdirAll(sub, 0755))
+	fst.Nil(t, FindToolchain(sub))
+
+	mod := filepath.Join(dir, "go.mod")
+	fst.NoError(t, os.WriteFile(mod, []byte("module a\n\ngo 1.22\n\ntoolchain go1.22.5 // comment\n"), 0644))
+	fst.Equal(t, &ProjectVersion{File: mod, Version: "go1.22.5"}, FindToolchain(sub))
+
+	// 最近的 go.mod 中没有 toolchain 时不再向上查找
+	fst.NoError(t, os.WriteFile(filepath.Join(dir, "a", "go.mod"), []byte("module a\n\ngo 1.22\n"), 0644))
+	fst.Nil(t, FindToolchain(sub))
+
+	// go.work 优先于同一目录中的 go.mod
+	work := filepath.Join(dir, "a", "go.work")
+	fst.NoError(t, os.WriteFile(work, []byte("go 1.23\n\ntoolchain go1.23.1\n\nuse ./b\n"), 0644))
+	fst.Equal(t, &ProjectVersion{File: work, Version: "go1.23.1"}, FindToolchain(sub))
+}
+
+func TestFindPin(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "a")
+	fst.NoError(t, os.MkdirAll(sub, 0755))
+	fst.Nil(t, FindPin(sub))
+
+	fp := filepath.Join(dir, PinFile)
+	fst.NoError(t, os.WriteFile(fp, []byte(" ~1.22 \n"), 0644))
+	fst.Equal(t, &ProjectVersion{File: fp, Version: "~1.22"}, FindPin(sub))
+
+	fst.NoError(t, os.WriteFile(fp, []byte("\n"), 0644))
+	fst.Nil(t, FindPin(sub))
+}
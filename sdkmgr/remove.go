@@ -0,0 +1,160 @@
+// Copyright(C) 2022 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2022/1/3
+
+package sdkmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// RemoveOptions Remove、Clean 的参数
+type RemoveOptions struct {
+	// Force 是否删除没有安装信息（见 Manifest）的目录，如手动放到 SDKDir 中的
+	Force bool
+}
+
+// Remove 删除指定的版本，会同时删除 $GOBIN 下对应的命令
+// version 也可以是版本约束，如 "<1.20"，会删除满足约束的已安装的最新版本。
+// 不是由 smart-go-dl 安装的目录会返回 ErrNotOwned，除非 opts.Force
+func (m *Manager) Remove(ctx context.Context, version string, opts RemoveOptions) error {
+	defer m.LinkLatest(ctx)
+
+	if v, err := ParseVersion(version); err == nil && v.IsLanguage() {
+		return fmt.Errorf("%q is a minor version, use patch version like %q", version, v.Name())
+	}
+
+	sdk, err := m.resolveWritable(ctx, version)
+	if err != nil {
+		return fmt.Errorf("remove %q: %w", version, err)
+	}
+	v := sdk.Version
+	// 变体版本不在版本列表中，需要在删除之前获取
+	vs, err := m.AllVersions(ctx)
+	if err != nil {
+		return err
+	}
+	if err = m.removeVersion(v, opts.Force); err != nil {
+		return err
+	}
+
+	// 删除的是次要版本的最新版本时，$GOBIN/go1.x 也已失效
+	if mv := vs.Get(v.Normalized); mv != nil && mv.Latest().Compare(v) == 0 {
+		if link := m.MinorBinPath(v); len(link) > 0 {
+			if err = os.Remove(link); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// removeVersion 删除版本的 GOROOT 和 $GOBIN/go1.x.y
+func (m *Manager) removeVersion(v *Version, force bool) error {
+	unlock := m.lockVersion(m.GOROOT(v))
+	defer unlock()
+
+	if err := m.checkOwned(m.GOROOT(v)); err != nil {
+		if !force {
+			return fmt.Errorf("%w, adopt it first or use force", err)
+		}
+		m.logPrint("remove", "force,", err)
+	}
+
+	if goBin := m.BinPath(v); len(goBin) > 0 {
+		m.logPrint("remove", goBin)
+		if err := os.Remove(goBin); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	sdkDir := m.GOROOT(v)
+	m.logPrint("remove", sdkDir)
+	if err := os.RemoveAll(sdkDir); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Clean 将go1.x的老版本删除掉，只保留最新的版本，被 lock 的版本会保留
+// version 也可以是版本约束，如 ~1.22、oldstable，会清理其所在的次要版本。
+// 变体版本是单独的次要版本，如 go1.22-acme，清理 go1.22 时不会删除 go1.22.5-acme。
+// 不是由 smart-go-dl 安装的目录会跳过，除非 opts.Force
+func (m *Manager) Clean(ctx context.Context, version string, opts RemoveOptions) error {
+	versions, err := m.AllVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if IsConstraint(version) {
+		v, err := m.resolve(version, versions.All())
+		if err != nil {
+			return err
+		}
+		version = v.Normalized
+	}
+
+	mv := versions.Get(version)
+	if mv == nil {
+		return fmt.Errorf("version %q not found", version)
+	}
+
+	if len(mv.PatchVersions) < 2 {
+		m.logPrint("clean", "no old versions need to be clean")
+		return nil
+	}
+
+	for i := 1; i < len(mv.PatchVersions); i++ {
+		cur := mv.PatchVersions[i]
+		if err = m.cleanVersion(cur, opts.Force); err != nil {
+			m.logPrint("clean", cur.Raw, "failed:", err)
+		}
+	}
+	return nil
+}
+
+// cleanVersion 删除未被 lock 的版本，只删除 SDKDir 中的，
+// 不是由 smart-go-dl 安装的会跳过，除非 force
+func (m *Manager) cleanVersion(v *Version, force bool) error {
+	if _, err := os.Stat(m.GOROOT(v)); err != nil && os.IsNotExist(err) {
+		return nil
+	}
+	if m.IsLocked(v) {
+		m.logPrint("clean", v.Raw, "locked")
+		return nil
+	}
+	err := m.removeVersion(v, force)
+	if errors.Is(err, ErrNotOwned) {
+		m.logPrint("clean", v.Raw, "skipped:", err)
+		return nil
+	}
+	return err
+}
+
+// Retire 删除次要版本已安装的所有版本，被 lock 的版本会保留
+// 若全部删除了，会同时删除 $GOBIN/go1.x
+func (m *Manager) Retire(ctx context.Context, mv *MinorVersion) error {
+	m.logPrint("retire", mv.NormalizedVersion)
+	for _, pv := range mv.PatchVersions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := m.cleanVersion(pv, false); err != nil {
+			return err
+		}
+	}
+	if m.MinorInstalled(mv) {
+		return nil
+	}
+	link := m.MinorBinPath(mv.Latest())
+	if len(link) == 0 {
+		return nil
+	}
+	m.logPrint("retire", "remove", link)
+	if err := os.Remove(link); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
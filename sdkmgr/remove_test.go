@@ -0,0 +1,48 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestManager_Remove_release(t *testing.T) {
+	ctx := context.Background()
+	m, err := New(Options{SDKDir: t.TempDir()})
+	fst.NoError(t, err)
+	for _, version := range []string{"go1.20", "go1.20.14", "go1.22.5"} {
+		fakeInstall(t, m, version)
+	}
+	v20, v2014 := mustParseVersion(t, "go1.20"), mustParseVersion(t, "go1.20.14")
+
+	// go1.20 是发布版本 go1.20.0，不是 go1.20 已安装的最新版本
+	sdk, err := m.Resolve(ctx, "go1.20")
+	fst.NoError(t, err)
+	fst.Equal(t, m.GOROOT(v20), sdk.GOROOT)
+
+	// $GOBIN/go1.20 是次要版本的命令，运行已安装的最新版本
+	sdk, err = m.ResolveLink(ctx, "go1.20")
+	fst.NoError(t, err)
+	fst.Equal(t, m.GOROOT(v2014), sdk.GOROOT)
+
+	fst.NoError(t, m.Lock(ctx, "go1.20"))
+	fst.True(t, m.IsLocked(v20))
+	fst.False(t, m.IsLocked(v2014))
+	fst.NoError(t, m.Unlock(ctx, "go1.20"))
+	fst.False(t, m.IsLocked(v20))
+
+	fst.NoError(t, m.Remove(ctx, "go1.20", RemoveOptions{}))
+	fst.False(t, m.Installed(v20))
+	fst.True(t, m.Installed(v2014))
+
+	fst.Error(t, m.Remove(ctx, "go1.20", RemoveOptions{}))
+	fst.True(t, m.Installed(v2014))
+
+	// 语言版本号需要使用发布版本号
+	fst.Error(t, m.Remove(ctx, "go1.22", RemoveOptions{}))
+}
@@ -0,0 +1,84 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestManager_resolve(t *testing.T) {
+	m, err := New(Options{SDKDir: t.TempDir()})
+	fst.NoError(t, err)
+	vs := ParseVersions([]string{"go1.20", "go1.20.14", "go1.21.0", "go1.21.10", "go1.22rc1", "gotip"})
+
+	got, err := m.resolve("<1.22", vs.All())
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.21.10", got.Raw)
+	fst.Equal(t, "go1.21", got.Normalized)
+
+	got, err = m.resolve("~1.20", vs.All())
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.20.14", got.Raw)
+
+	got, err = m.resolve("gotip", vs.All())
+	fst.NoError(t, err)
+	fst.Equal(t, "gotip", got.Raw)
+
+	_, err = m.resolve(">1.22", vs.All())
+	fst.Error(t, err)
+
+	// 只有次要版本号的边界只比较次要版本，如 install '<=1.22'
+	vs = ParseVersions([]string{"go1.20", "go1.20.14", "go1.21.0", "go1.21.10", "go1.22.0", "go1.22.5", "go1.23.1"})
+	for constraint, want := range map[string]string{
+		"<=1.22":        "go1.22.5",
+		"<=1.20":        "go1.20.14",
+		"<1.21":         "go1.20.14",
+		">1.21, <1.23":  "go1.22.5",
+		">=1.20, <1.21": "go1.20.14",
+	} {
+		got, err = m.resolve(constraint, vs.All())
+		fst.NoError(t, err)
+		fst.Equal(t, want, got.Raw)
+	}
+}
+
+func TestManager_Resolve_minorBound(t *testing.T) {
+	ctx := context.Background()
+	m, err := New(Options{SDKDir: t.TempDir()})
+	fst.NoError(t, err)
+	for _, v := range []string{"go1.20", "go1.20.14", "go1.21.0", "go1.22.0", "go1.22.5", "go1.23.1"} {
+		fakeInstall(t, m, v)
+	}
+	for constraint, want := range map[string]string{
+		"<=1.22": "go1.22.5",
+		"<=1.20": "go1.20.14",
+		">1.21":  "go1.23.1",
+		"<1.22":  "go1.21.0",
+		"=1.20":  "go1.20.14",
+	} {
+		sdk, err := m.Resolve(ctx, constraint)
+		fst.NoError(t, err)
+		fst.Equal(t, want, sdk.Version.Raw)
+	}
+
+	// lock、remove 使用的也是同样的解析
+	fst.NoError(t, m.Lock(ctx, "<=1.22"))
+	v, err := ParseVersion("go1.22.5")
+	fst.NoError(t, err)
+	fst.True(t, m.IsLocked(v))
+}
+
+func TestIsConstraint(t *testing.T) {
+	fst.False(t, IsConstraint("go1.22"))
+	fst.False(t, IsConstraint("go1.22.5"))
+	fst.False(t, IsConstraint("go1.26rc1"))
+	fst.False(t, IsConstraint("gotip"))
+	fst.True(t, IsConstraint("1.22"))
+	fst.True(t, IsConstraint(">=1.22"))
+	fst.True(t, IsConstraint("stable"))
+}
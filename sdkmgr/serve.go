@@ -0,0 +1,244 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ServeOptions Handler 的参数
+type ServeOptions struct {
+	// IndexMaxAge 版本列表的有效期，请求版本列表时超过此时间会先更新，为 0 时不更新
+	IndexMaxAge time.Duration
+}
+
+// Handler 以 HTTP 的方式提供打包文件和版本列表，作为其他 smart-go-dl 的镜像使用，
+// 地址的格式和官方下载地址一致，可以直接配置为其他机器的 TarURLPrefix：
+//
+//	/go1.22.5.linux-amd64.tar.gz        : 打包文件，不在缓存中时会使用配置的来源下载并缓存
+//	/go1.22.5.src.tar.gz                : 源码打包文件，同上
+//	/go1.22.5.linux-amd64.tar.gz.sha256 : 打包文件的 SHA256
+//	/SHA256SUMS                         : 缓存中所有打包文件的 SHA256
+//	/?mode=json                         : go.dev 格式的版本列表，可以配置为 "feed:{url}"
+//
+// ctx 为服务的 context，从来源下载打包文件并缓存时使用，而不是请求的 context，
+// 这样某个客户端断开时不会取消下载，同时在等待同一个打包文件的其他请求仍然可以使用它
+func (m *Manager) Handler(ctx context.Context, opts ServeOptions) http.Handler {
+	return &server{ctx: ctx, m: m, opts: opts}
+}
+
+type server struct {
+	ctx  context.Context
+	m    *Manager
+	opts ServeOptions
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	s.m.logPrint("serve", r.RemoteAddr, r.Method, r.URL.String())
+	switch {
+	case name == "":
+		s.serveFeed(w, r)
+	case name == bundleSumsName:
+		s.serveSums(w, r)
+	case strings.HasSuffix(name, ".sha256"):
+		s.serveArchive(w, r, strings.TrimSuffix(name, ".sha256"), true)
+	default:
+		s.serveArchive(w, r, name, false)
+	}
+}
+
+func (s *server) serveArchive(w http.ResponseWriter, r *http.Request, name string, sum bool) {
+	req, err := parseArchiveName(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	fp, digest, err := s.archive(s.ctx, req)
+	if err != nil {
+		s.m.logPrint("serve", name, "failed:", err)
+		code := http.StatusBadGateway
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, ErrOffline) {
+			code = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+	if sum {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintln(w, digest)
+		return
+	}
+	f, err := os.Open(fp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, name, info.ModTime(), f)
+}
+
+// archive 返回打包文件的路径和 SHA256，不在缓存中时会使用配置的来源获取并缓存，
+// 同一个打包文件同时只会获取一次
+func (s *server) archive(ctx context.Context, req *FetchRequest) (string, string, error) {
+	name := req.ArchiveName()
+	unlock := s.m.lockVersion("serve:" + name)
+	defer unlock()
+
+	cache := &archiveCache{m: s.m}
+	if ar, err := cache.Fetch(ctx, req); err == nil {
+		rec, err := s.m.readCacheRecord(name)
+		if err == nil {
+			return ar.Path, rec.SHA256, nil
+		}
+	}
+
+	if err := os.MkdirAll(s.m.opts.DataDir, 0755); err != nil {
+		return "", "", err
+	}
+	dir, err := os.MkdirTemp(s.m.opts.DataDir, ".serve-")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.RemoveAll(dir)
+	req.Dir = dir
+
+	var local string
+	err = s.m.fetch(ctx, req, func(ar *Archive) error {
+		// 只提供官方格式的打包文件
+		if ar.StripComponents != 1 || filepath.Base(ar.Path) != name {
+			return fmt.Errorf("%s is not an official archive", ar.Path)
+		}
+		if !ar.Temporary {
+			local = ar.Path
+		}
+		return nil
+	})
+	if err != nil {
+		return "", "", err
+	}
+	if len(local) > 0 {
+		digest, err := fileSHA256(ctx, local)
+		return local, digest, err
+	}
+	rec, err := s.m.readCacheRecord(name)
+	if err != nil {
+		return "", "", fmt.Errorf("archive cache is disabled or not writable: %w", err)
+	}
+	return filepath.Join(s.m.cacheBlobDir(), rec.File), rec.SHA256, nil
+}
+
+// parseArchiveName 解析官方打包文件名，如 go1.22.5.linux-amd64.tar.gz、go1.22.5.src.tar.gz
+func parseArchiveName(name string) (*FetchRequest, error) {
+	base, ok := strings.CutSuffix(name, ".tar.gz")
+	if !ok {
+		base, ok = strings.CutSuffix(name, ".zip")
+	}
+	i := strings.LastIndex(base, ".")
+	if !ok || i < 0 {
+		return nil, fmt.Errorf("invalid archive name %q", name)
+	}
+	req := &FetchRequest{Source: base[i+1:] == "src"}
+	if !req.Source {
+		if req.GOOS, req.GOARCH, ok = strings.Cut(base[i+1:], "-"); !ok {
+			return nil, fmt.Errorf("invalid archive name %q", name)
+		}
+	}
+	v, err := ParseVersion(base[:i])
+	if err != nil || v.IsTip() {
+		return nil, fmt.Errorf("invalid archive name %q", name)
+	}
+	req.Version = v
+	if req.ArchiveName() != name {
+		return nil, fmt.Errorf("invalid archive name %q", name)
+	}
+	return req, nil
+}
+
+func (s *server) serveSums(w http.ResponseWriter, r *http.Request) {
+	list, err := s.m.CacheList(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for _, e := range list {
+		fmt.Fprintf(w, "%s  %s\n", e.SHA256, e.Name)
+	}
+}
+
+// serveFeed 输出 go.dev 格式的版本列表，只有缓存中的打包文件会在 files 中
+func (s *server) serveFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if s.opts.IndexMaxAge > 0 && !s.m.Offline() {
+		if err := s.m.RefreshIfStale(ctx, s.opts.IndexMaxAge); err != nil {
+			s.m.logPrint("serve", "refresh version index failed:", err)
+		}
+	}
+	versions, err := s.m.Versions(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cached, err := s.m.CacheList(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	files := make(map[string][]FeedFile)
+	for _, e := range cached {
+		req, err := parseArchiveName(e.Name)
+		if err != nil {
+			continue
+		}
+		kind := "archive"
+		if req.Source {
+			kind = "source"
+		}
+		files[req.Version.Raw] = append(files[req.Version.Raw], FeedFile{
+			Filename: e.Name,
+			OS:       req.GOOS,
+			Arch:     req.GOARCH,
+			Version:  req.Version.Raw,
+			SHA256:   e.SHA256,
+			Size:     e.Size,
+			Kind:     kind,
+		})
+	}
+	releases := []FeedRelease{}
+	for _, v := range versions.All() {
+		if v.IsTip() {
+			continue
+		}
+		releases = append(releases, FeedRelease{
+			Version: v.Raw,
+			Stable:  v.IsNormal(),
+			Files:   files[v.Raw],
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", " ")
+	_ = enc.Encode(releases)
+}
@@ -0,0 +1,161 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+func TestManager_Handler(t *testing.T) {
+	var hits int
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = w.Write(archiveOf(t, path.Base(r.URL.Path)))
+	}))
+	defer upstream.Close()
+
+	indexDir := t.TempDir()
+	for _, version := range []string{"go1.22.5", "go1.23rc1"} {
+		fst.NoError(t, os.WriteFile(filepath.Join(indexDir, version+".linux-amd64.tar.gz"), nil, 0644))
+	}
+	mirror, err := New(Options{
+		SDKDir:  t.TempDir(),
+		Mirrors: []string{upstream.URL},
+		Indexes: []Index{&Dir{Path: indexDir}},
+	})
+	fst.NoError(t, err)
+	ts := httptest.NewServer(mirror.Handler(context.Background(), ServeOptions{}))
+	defer ts.Close()
+
+	get := func(t *testing.T, u string) (int, string) {
+		t.Helper()
+		resp, err := http.Get(ts.URL + u)
+		fst.NoError(t, err)
+		defer resp.Body.Close()
+		bf, err := io.ReadAll(resp.Body)
+		fst.NoError(t, err)
+		return resp.StatusCode, string(bf)
+	}
+
+	ctx := context.Background()
+	v := mustParseVersion(t, "go1.22.5")
+	name := v.ArchiveName(runtime.GOOS, runtime.GOARCH)
+
+	// 多个客户端安装同一个版本，只会从上游下载一次
+	for i := 0; i < 3; i++ {
+		client, err := New(Options{
+			SDKDir:    t.TempDir(),
+			CacheSize: -1,
+			Mirrors:   []string{ts.URL + "/"},
+			Indexes:   []Index{&FeedIndex{URL: ts.URL + "/?mode=json&include=all"}},
+		})
+		fst.NoError(t, err)
+		fst.NoError(t, client.Refresh(ctx))
+		vs, err := client.Versions(ctx)
+		fst.NoError(t, err)
+		fst.Equal(t, "go1.22.5", vs.Get("go1.22").Latest().Raw)
+		fst.NoError(t, client.Download(ctx, v))
+		fst.True(t, client.Installed(v))
+	}
+	fst.Equal(t, 1, hits)
+
+	sum := sha256.Sum256(archiveOf(t, name))
+	want := hex.EncodeToString(sum[:])
+	code, body := get(t, "/"+name+".sha256")
+	fst.Equal(t, http.StatusOK, code)
+	fst.Equal(t, want, strings.TrimSpace(body))
+
+	code, body = get(t, "/SHA256SUMS")
+	fst.Equal(t, http.StatusOK, code)
+	fst.Equal(t, want+"  "+name+"\n", body)
+
+	code, body = get(t, "/?mode=json")
+	fst.Equal(t, http.StatusOK, code)
+	fst.True(t, strings.Contains(body, `"sha256": "`+want+`"`))
+	fst.True(t, strings.Contains(body, `"version": "go1.23rc1"`))
+
+	for _, u := range []string{"/go1.22.5.sources.tar.gz", "/gotip.linux-amd64.tar.gz", "/../etc/passwd", "/go1.22.5.windows-amd64.tar.gz"} {
+		code, _ = get(t, u)
+		fst.Equal(t, http.StatusNotFound, code)
+	}
+	fst.Equal(t, 1, hits)
+
+	// 源码打包文件，用于没有官方二进制打包文件的平台
+	code, body = get(t, "/go1.22.5.src.tar.gz")
+	fst.Equal(t, http.StatusOK, code)
+	fst.Equal(t, string(archiveOf(t, "go1.22.5.src.tar.gz")), body)
+	fst.Equal(t, 2, hits)
+	code, body = get(t, "/?mode=json")
+	fst.Equal(t, http.StatusOK, code)
+	fst.True(t, strings.Contains(body, `"kind": "source"`))
+}
+
+func TestManager_Handler_clientCanceled(t *testing.T) {
+	var hits atomic.Int32
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		started <- struct{}{}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = w.Write(archiveOf(t, path.Base(r.URL.Path)))
+	}))
+	defer upstream.Close()
+
+	mirror, err := New(Options{
+		SDKDir:  t.TempDir(),
+		Mirrors: []string{upstream.URL},
+	})
+	fst.NoError(t, err)
+	ts := httptest.NewServer(mirror.Handler(context.Background(), ServeOptions{}))
+	defer ts.Close()
+	u := ts.URL + "/" + mustParseVersion(t, "go1.22.5").ArchiveName("linux", "amd64")
+
+	// 第一个客户端在下载过程中断开
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		done <- err
+	}()
+	<-started
+	cancel()
+	fst.Error(t, <-done)
+	time.Sleep(100 * time.Millisecond)
+
+	// 等待同一个打包文件的客户端可以使用之前的下载
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+	}()
+	resp, err := http.Get(u)
+	fst.NoError(t, err)
+	defer resp.Body.Close()
+	fst.Equal(t, http.StatusOK, resp.StatusCode)
+	fst.Equal(t, int32(1), hits.Load())
+}
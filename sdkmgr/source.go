@@ -0,0 +1,224 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Index 版本列表的来源，如 golang/dl 的 git 仓库、go.dev 的 JSON 接口
+type Index interface {
+	// Name 名称，用于日志
+	Name() string
+
+	// Refresh 从远端更新版本列表到本地缓存
+	Refresh(ctx context.Context) error
+
+	// Versions 本地缓存的所有版本号，如 go1.22.5、go1.26rc1，不需要排序
+	Versions(ctx context.Context) ([]string, error)
+}
+
+// Fetcher 下载 Go SDK 打包文件的来源，如官方下载地址、GOPROXY、本地目录
+type Fetcher interface {
+	// Name 名称，用于日志
+	Name() string
+
+	// Fetch 获取指定版本、平台的打包文件
+	Fetch(ctx context.Context, req *FetchRequest) (*Archive, error)
+}
+
+// LocalFetcher 不需要访问网络的打包文件来源，如本地目录，离线模式下只会使用这类来源
+type LocalFetcher interface {
+	Fetcher
+
+	// Local 是否只使用本地的文件
+	Local() bool
+}
+
+func isLocal(f Fetcher) bool {
+	lf, ok := f.(LocalFetcher)
+	return ok && lf.Local()
+}
+
+// FetchRequest 获取打包文件的参数
+type FetchRequest struct {
+	Version *Version
+
+	GOOS   string
+	GOARCH string
+
+	// Source 是否获取源码打包文件，如 go1.22.5.src.tar.gz，用于没有官方二进制打包文件时从源码构建
+	Source bool
+
+	// Dir 可以用于存放下载文件的目录，如 ~/sdk/go1.22.5
+	Dir string
+}
+
+// ArchiveName 官方打包文件的名称，如 go1.22.5.linux-amd64.tar.gz，源码为 go1.22.5.src.tar.gz
+func (req *FetchRequest) ArchiveName() string {
+	if req.Source {
+		return req.Version.SourceArchiveName()
+	}
+	return req.Version.ArchiveName(req.GOOS, req.GOARCH)
+}
+
+// Archive 获取到的打包文件
+type Archive struct {
+	// Path 打包文件的路径，支持 .tar.gz 和 .zip
+	Path string
+
+	// StripComponents 解压时忽略掉的前 N 层目录，官方打包文件为 1，即 go/
+	StripComponents uint
+
+	// Temporary 是否临时文件，解压后会被删除
+	Temporary bool
+
+	// fetcher 获取到该打包文件的来源名称，用于安装信息
+	fetcher string
+}
+
+// managed 内置的实现，会使用所属 Manager 的 HTTPClient、日志和数据目录，
+// 所以不能在多个 Manager 之间共享
+type managed interface {
+	setManager(m *Manager)
+}
+
+// ParseIndex 解析版本列表来源的配置
+//
+//	git            golang/dl 的 git 仓库，git:{repo} 可以指定仓库地址
+//	godev          go.dev 的 JSON 接口
+//	feed:{url}     和 go.dev 格式相同的 JSON 接口，如内部镜像
+//	goproxy        GOPROXY 中的 golang.org/toolchain 模块，goproxy:{url} 可以指定地址
+//	dir:{path}     本地目录中的打包文件
+func ParseIndex(spec string) (Index, error) {
+	kind, arg, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	switch kind {
+	case "git":
+		return &GitIndex{Repo: arg}, nil
+	case "godev":
+		return &FeedIndex{}, nil
+	case "feed":
+		if len(arg) == 0 {
+			return nil, fmt.Errorf("invalid index %q, url is required", spec)
+		}
+		return &FeedIndex{URL: arg}, nil
+	case "goproxy":
+		return &GoProxy{URL: arg}, nil
+	case "dir":
+		if len(arg) == 0 {
+			return nil, fmt.Errorf("invalid index %q, path is required", spec)
+		}
+		return newDir(arg)
+	default:
+		return nil, fmt.Errorf("unknown index %q", spec)
+	}
+}
+
+// ParseFetcher 解析打包文件来源的配置
+//
+//	mirror              Options.Mirrors 中的下载地址
+//	mirror:{url},{url}  指定的下载地址前缀，如 https://dl.google.com/go/
+//	godev               go.dev 官方下载地址
+//	goproxy             GOPROXY 中的 golang.org/toolchain 模块，goproxy:{url} 可以指定地址
+//	dir:{path}          本地目录中的打包文件
+func ParseFetcher(spec string) (Fetcher, error) {
+	kind, arg, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	switch kind {
+	case "mirror":
+		var urls []string
+		if len(arg) > 0 {
+			urls = strings.Split(arg, ",")
+		}
+		return &Mirror{URLs: urls}, nil
+	case "godev":
+		return &Mirror{URLs: DefaultMirrors[:1]}, nil
+	case "goproxy":
+		return &GoProxy{URL: arg}, nil
+	case "dir":
+		if len(arg) == 0 {
+			return nil, fmt.Errorf("invalid fetcher %q, path is required", spec)
+		}
+		return newDir(arg)
+	default:
+		return nil, fmt.Errorf("unknown fetcher %q", spec)
+	}
+}
+
+// indexStatusFile 记录版本列表上次成功更新的时间
+const indexStatusFile = "download.status"
+
+// IndexTime 版本列表上次成功更新的时间，从未更新过时返回零值
+func (m *Manager) IndexTime() time.Time {
+	info, err := os.Stat(filepath.Join(m.opts.DataDir, indexStatusFile))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// RefreshIfStale 版本列表距离上次成功更新超过 maxAge 时，才更新版本列表
+func (m *Manager) RefreshIfStale(ctx context.Context, maxAge time.Duration) error {
+	if age := time.Since(m.IndexTime()); age < maxAge {
+		m.logPrint("refresh", "skipped, version index updated", age.Round(time.Second), "ago")
+		return nil
+	}
+	return m.Refresh(ctx)
+}
+
+// Refresh 依次使用配置的版本列表来源更新版本列表，直到有一个成功
+func (m *Manager) Refresh(ctx context.Context) error {
+	if m.opts.Offline {
+		return ErrOffline
+	}
+	m.refreshMux.Lock()
+	defer m.refreshMux.Unlock()
+
+	if err := os.MkdirAll(m.opts.DataDir, 0755); err != nil {
+		return err
+	}
+	var err error
+	for _, idx := range m.indexes {
+		if err = idx.Refresh(ctx); err == nil {
+			fp := filepath.Join(m.opts.DataDir, indexStatusFile)
+			return os.WriteFile(fp, []byte(time.Now().String()), 0644)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		m.logPrint("refresh", idx.Name(), "failed:", err)
+	}
+	return err
+}
+
+// Versions 获取所有的版本信息，按照版本倒序排列
+// 会依次使用配置的版本列表来源，使用第一个有数据的，版本列表需要先使用 Refresh 更新
+func (m *Manager) Versions(ctx context.Context) (Versions, error) {
+	// 导入的离线包中的版本列表快照，见 ImportBundle
+	bundled, _ := m.bundleIndex()
+	var err error
+	for _, idx := range m.indexes {
+		var vs []string
+		vs, err = idx.Versions(ctx)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err == nil && len(vs) > 0 {
+			return ParseVersions(append(vs, bundled...)), nil
+		}
+		m.logPrint("versions", idx.Name(), "no versions, err=", err)
+	}
+	if len(bundled) > 0 {
+		return ParseVersions(bundled), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseVersions(nil), nil
+}
@@ -0,0 +1,180 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+func TestParseIndex(t *testing.T) {
+	for spec, want := range map[string]string{
+		"git":                            "git",
+		"godev":                          "godev",
+		"feed:https://example.com/dl":    "feed:https://example.com/dl",
+		"goproxy:https://goproxy.cn/":    "goproxy:https://goproxy.cn",
+		"dir:" + filepath.FromSlash("/"): "dir:" + filepath.FromSlash("/"),
+	} {
+		idx, err := ParseIndex(spec)
+		fst.NoError(t, err)
+		fst.Equal(t, want, idx.Name())
+	}
+	for _, spec := range []string{"", "svn", "feed", "dir"} {
+		_, err := ParseIndex(spec)
+		fst.Error(t, err)
+	}
+}
+
+func TestParseFetcher(t *testing.T) {
+	for spec, want := range map[string]string{
+		"mirror:https://a/go/,https://b/go/": "mirror:https://a/go/,https://b/go/",
+		"godev":                              "mirror:https://go.dev/dl/",
+		"goproxy:https://goproxy.cn":         "goproxy:https://goproxy.cn",
+	} {
+		f, err := ParseFetcher(spec)
+		fst.NoError(t, err)
+		fst.Equal(t, want, f.Name())
+	}
+	for _, spec := range []string{"", "git", "dir"} {
+		_, err := ParseFetcher(spec)
+		fst.Error(t, err)
+	}
+}
+
+// toolchainZip 生成 golang.org/toolchain 模块格式的 zip 文件
+func toolchainZip(t *testing.T, mv string) []byte {
+	t.Helper()
+	bf := &bytes.Buffer{}
+	zw := zip.NewWriter(bf)
+	w, err := zw.Create("golang.org/toolchain@" + mv + "/bin/go" + exe())
+	fst.NoError(t, err)
+	_, err = w.Write([]byte("go"))
+	fst.NoError(t, err)
+	fst.NoError(t, zw.Close())
+	return bf.Bytes()
+}
+
+func TestManager_sources(t *testing.T) {
+	mv := "v0.0.1-go1.22.5." + runtime.GOOS + "-" + runtime.GOARCH
+	var feedHits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dl/":
+			feedHits++
+			_, _ = w.Write([]byte(`[{"version":"go1.23.1","stable":true},{"version":"go1.24rc1","stable":false}]`))
+		case "/proxy/golang.org/toolchain/@v/list":
+			_, _ = w.Write([]byte("v0.0.1-go1.22.5.linux-amd64\nv0.0.1-go1.22.5.darwin-arm64\nv0.0.1-go1.21.0.linux-amd64\n"))
+		case "/proxy/golang.org/toolchain/@v/" + mv + ".zip":
+			_, _ = w.Write(toolchainZip(t, mv))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	names := func(vs Versions) []string {
+		var result []string
+		for _, v := range vs.All() {
+			result = append(result, v.Raw)
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	t.Run("feed", func(t *testing.T) {
+		m, err := New(Options{
+			SDKDir:  t.TempDir(),
+			Indexes: []Index{&FeedIndex{URL: ts.URL + "/404"}, &FeedIndex{URL: ts.URL + "/dl/"}},
+		})
+		fst.NoError(t, err)
+		fst.True(t, m.IndexTime().IsZero())
+		fst.NoError(t, m.Refresh(ctx))
+		fst.False(t, m.IndexTime().IsZero())
+		vs, err := m.Versions(ctx)
+		fst.NoError(t, err)
+		fst.Equal(t, []string{"go1.23.1", "go1.24rc1", "gotip"}, names(vs))
+
+		// 未过期时不会更新
+		fst.Equal(t, 1, feedHits)
+		fst.NoError(t, m.RefreshIfStale(ctx, time.Hour))
+		fst.Equal(t, 1, feedHits)
+		fst.NoError(t, m.RefreshIfStale(ctx, 0))
+		fst.Equal(t, 2, feedHits)
+	})
+
+	t.Run("goproxy", func(t *testing.T) {
+		gp := &GoProxy{URL: ts.URL + "/proxy/"}
+		m, err := New(Options{
+			SDKDir:   t.TempDir(),
+			Indexes:  []Index{gp},
+			Fetchers: []Fetcher{&Mirror{URLs: []string{ts.URL + "/404/"}}, gp},
+		})
+		fst.NoError(t, err)
+		fst.NoError(t, m.Refresh(ctx))
+		vs, err := m.Versions(ctx)
+		fst.NoError(t, err)
+		fst.Equal(t, []string{"go1.21.0", "go1.22.5", "gotip"}, names(vs))
+
+		v, err := ParseVersion("go1.22.5")
+		fst.NoError(t, err)
+		fst.NoError(t, m.Download(ctx, v))
+		fst.True(t, m.Installed(v))
+		if !isWindows() {
+			info, err := os.Stat(filepath.Join(m.GOROOT(v), "bin", "go"))
+			fst.NoError(t, err)
+			fst.Equal(t, os.FileMode(0111), info.Mode()&0111)
+		}
+
+		// go1.21 之前的版本没有发布到 GOPROXY
+		_, err = gp.Fetch(ctx, &FetchRequest{Version: mustParseVersion(t, "go1.20.1"), GOOS: "linux", GOARCH: "amd64"})
+		fst.Error(t, err)
+	})
+
+	t.Run("dir", func(t *testing.T) {
+		dir := t.TempDir()
+		v := mustParseVersion(t, "go1.22.5")
+		name := v.ArchiveName(runtime.GOOS, runtime.GOARCH)
+		fst.NoError(t, os.WriteFile(filepath.Join(dir, name), archiveOf(t, name), 0644))
+		fst.NoError(t, os.WriteFile(filepath.Join(dir, "go1.21rc2.linux-arm64.tar.gz"), nil, 0644))
+		fst.NoError(t, os.WriteFile(filepath.Join(dir, "readme.txt"), nil, 0644))
+
+		d, err := newDir(dir)
+		fst.NoError(t, err)
+		m, err := New(Options{
+			SDKDir:   t.TempDir(),
+			Indexes:  []Index{d},
+			Fetchers: []Fetcher{d},
+		})
+		fst.NoError(t, err)
+		vs, err := m.Versions(ctx)
+		fst.NoError(t, err)
+		fst.Equal(t, []string{"go1.21rc2", "go1.22.5", "gotip"}, names(vs))
+
+		fst.NoError(t, m.Download(ctx, v))
+		fst.True(t, m.Installed(v))
+		// 本地目录中的打包文件不会被删除
+		_, err = os.Stat(filepath.Join(dir, name))
+		fst.NoError(t, err)
+	})
+}
+
+func mustParseVersion(t *testing.T, version string) *Version {
+	t.Helper()
+	v, err := ParseVersion(version)
+	fst.NoError(t, err)
+	return v
+}
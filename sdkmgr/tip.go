@@ -0,0 +1,217 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultGoRepo Go 源码仓库的默认地址
+const DefaultGoRepo = "https://go.googlesource.com/go"
+
+// tipRepoDir 在 DataDir 中的 Go 源码仓库（bare），更新时只获取新的提交
+const tipRepoDir = "go.git"
+
+// tipInfoFile 从源码构建的 gotip 的信息，在其 GOROOT 下
+const tipInfoFile = ".smart-go-dl-tip.json"
+
+// defaultTipRef gotip 默认使用的分支
+const defaultTipRef = "master"
+
+// TipInfo 从源码构建的 gotip 的信息
+type TipInfo struct {
+	// Ref 构建时使用的分支、tag 或者提交，如 master、release-branch.go1.23
+	Ref string `json:"ref"`
+
+	// Commit 构建的提交
+	Commit string `json:"commit"`
+
+	// Built 构建完成的时间
+	Built time.Time `json:"built"`
+}
+
+// TipInfo 已安装的 gotip 的信息
+func (m *Manager) TipInfo() (*TipInfo, error) {
+	bf, err := os.ReadFile(filepath.Join(m.GOROOT(gotipVersion()), tipInfoFile))
+	if err != nil {
+		return nil, err
+	}
+	info := &TipInfo{}
+	if err = json.Unmarshal(bf, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (m *Manager) goRepo() string {
+	if len(m.opts.GoRepo) == 0 {
+		return DefaultGoRepo
+	}
+	return m.opts.GoRepo
+}
+
+// InstallTip 从源码构建并安装 gotip
+//
+// ref: 分支、tag 或者提交，如 master、release-branch.go1.23，
+// 为空时使用上次安装时的 ref，首次安装时为 master
+//
+// Go 源码仓库会 clone 到 {DataDir}/go.git，之后只获取新的提交；提交未变化时不会重新构建。
+// 引导版本使用已安装的、满足要求的最新正式版本，若配置了 GOROOT_BOOTSTRAP 环境变量，会使用它。
+// 构建在临时目录 {SDKDir}/.gotip.staging 中进行，成功后才会替换已安装的 gotip
+func (m *Manager) InstallTip(ctx context.Context, ref string) (*SDK, error) {
+	if p := m.Platform(); p != CurrentPlatform() {
+		return nil, fmt.Errorf("gotip can only be built for the current platform, not %s", p)
+	}
+	tip := gotipVersion()
+	if len(ref) == 0 {
+		ref = defaultTipRef
+		if info, err := m.TipInfo(); err == nil && len(info.Ref) > 0 {
+			ref = info.Ref
+		}
+	}
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid ref %q", ref)
+	}
+
+	unlock := m.lockVersion(m.GOROOT(tip))
+	defer unlock()
+	if err := os.MkdirAll(m.opts.SDKDir, 0755); err != nil {
+		return nil, err
+	}
+	unlockFile, err := lockFile(ctx, filepath.Join(m.opts.SDKDir, "."+tip.Name()+".lock"))
+	if err != nil {
+		return nil, err
+	}
+	defer unlockFile()
+
+	repo, err := m.syncTipRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := m.resolveTipRef(ctx, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := m.TipInfo(); err == nil && info.Commit == commit && m.Installed(tip) {
+		m.logPrint("gotip", "already at", ref, commit)
+		return m.newSDK(tip), m.linkTip()
+	}
+
+	staging := filepath.Join(m.opts.SDKDir, "."+tip.Name()+".staging")
+	_ = os.RemoveAll(staging)
+	defer os.RemoveAll(staging)
+	if _, err = m.git(ctx, m.opts.SDKDir, "clone", "--local", "--no-checkout", repo, staging); err != nil {
+		return nil, err
+	}
+	if _, err = m.git(ctx, staging, "-c", "advice.detachedHead=false", "checkout", "--detach", commit); err != nil {
+		return nil, err
+	}
+
+	minVer, err := sourceMinBootstrap(staging)
+	if err != nil {
+		return nil, err
+	}
+	bootstrap, err := m.findBootstrap(ctx, minVer)
+	if err != nil {
+		return nil, err
+	}
+	m.logPrint("gotip", "building", ref, commit)
+	if err = m.makeBash(ctx, staging, bootstrap); err != nil {
+		return nil, err
+	}
+
+	info := &TipInfo{
+		Ref:    ref,
+		Commit: commit,
+		Built:  time.Now(),
+	}
+	bf, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(filepath.Join(staging, tipInfoFile), bf, 0644); err != nil {
+		return nil, err
+	}
+	mf := &Manifest{Source: m.goRepo() + "@" + commit}
+	if err = m.installGOROOT(staging, tip, mf); err != nil {
+		return nil, err
+	}
+	return m.newSDK(tip), m.linkTip()
+}
+
+// linkTip 创建 $GOBIN/gotip
+func (m *Manager) linkTip() error {
+	goBinTo := m.BinPath(gotipVersion())
+	if len(m.opts.Shim) == 0 || len(goBinTo) == 0 {
+		return nil
+	}
+	if err := m.createLink(m.opts.Shim, goBinTo); err != nil {
+		return err
+	}
+	m.logger.Printf("Success. You may now run '%s'\n", filepath.Base(goBinTo))
+	return nil
+}
+
+// syncTipRepo 下载或者更新 {DataDir}/go.git，离线模式下只使用已有的仓库
+func (m *Manager) syncTipRepo(ctx context.Context) (string, error) {
+	repo := filepath.Join(m.opts.DataDir, tipRepoDir)
+	_, err := os.Stat(filepath.Join(repo, "HEAD"))
+	exists := err == nil
+	if m.opts.Offline {
+		if !exists {
+			return "", fmt.Errorf("go source repository not found: %w", ErrOffline)
+		}
+		return repo, nil
+	}
+	if exists {
+		_, err = m.git(ctx, repo, "fetch", "--prune", "--tags", "origin")
+		return repo, err
+	}
+
+	if err = os.MkdirAll(m.opts.DataDir, 0755); err != nil {
+		return "", err
+	}
+	tmp := repo + ".tmp"
+	_ = os.RemoveAll(tmp)
+	defer os.RemoveAll(tmp)
+	if _, err = m.git(ctx, m.opts.DataDir, "clone", "--bare", m.goRepo(), tmp); err != nil {
+		return "", err
+	}
+	// bare 仓库默认不会更新分支，需要配置 fetch 的 refspec
+	if _, err = m.git(ctx, tmp, "config", "remote.origin.fetch", "+refs/heads/*:refs/heads/*"); err != nil {
+		return "", err
+	}
+	return repo, os.Rename(tmp, repo)
+}
+
+// resolveTipRef 查找 ref 对应的提交，不在已获取的分支、tag 中时，会单独获取它
+func (m *Manager) resolveTipRef(ctx context.Context, repo string, ref string) (string, error) {
+	commit, err := m.git(ctx, repo, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err == nil && len(commit) > 0 {
+		return commit, nil
+	}
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if !m.opts.Offline {
+		if _, err = m.git(ctx, repo, "fetch", "origin", ref); err == nil {
+			commit, err = m.git(ctx, repo, "rev-parse", "--verify", "--quiet", "FETCH_HEAD^{commit}")
+			if err == nil && len(commit) > 0 {
+				return commit, nil
+			}
+		}
+	}
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	return "", errors.Join(fmt.Errorf("ref %q not found in %s", ref, m.goRepo()), err)
+}
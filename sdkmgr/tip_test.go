@@ -0,0 +1,155 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+//go:build !windows
+
+package sdkmgr
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+// gitRun 在 dir 中执行 git 命令
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// newGoRepo 创建模拟 Go 源码仓库的 bare 仓库，返回仓库地址和工作目录
+func newGoRepo(t *testing.T) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	upstream := filepath.Join(t.TempDir(), "go.git")
+	gitRun(t, t.TempDir(), "init", "--bare", "-b", "master", upstream)
+
+	work := t.TempDir()
+	gitRun(t, work, "init", "-b", "master")
+	files := map[string]string{
+		"VERSION":                   "devel 1",
+		"src/make.bash":             fakeMakeBash,
+		"src/cmd/dist/buildtool.go": "package main\n\nvar minBootstrapVersion = \"go1.22.6\"\n",
+	}
+	for name, content := range files {
+		fp := filepath.Join(work, name)
+		fst.NoError(t, os.MkdirAll(filepath.Dir(fp), 0755))
+		fst.NoError(t, os.WriteFile(fp, []byte(content), 0755))
+	}
+	gitRun(t, work, "add", "-A")
+	gitRun(t, work, "commit", "-m", "init")
+	gitRun(t, work, "remote", "add", "origin", upstream)
+	gitRun(t, work, "push", "origin", "master")
+	return upstream, work
+}
+
+func TestManager_InstallTip(t *testing.T) {
+	upstream, work := newGoRepo(t)
+	buildLog := filepath.Join(t.TempDir(), "build.log")
+	t.Setenv("BUILD_LOG", buildLog)
+	t.Setenv("GOROOT_BOOTSTRAP", "")
+	builds := func() int {
+		bf, _ := os.ReadFile(buildLog)
+		return strings.Count(string(bf), "built")
+	}
+
+	dir := t.TempDir()
+	m, err := New(Options{
+		SDKDir: filepath.Join(dir, "sdk"),
+		GOBIN:  filepath.Join(dir, "bin"),
+		Shim:   os.Args[0],
+		GoRepo: upstream,
+	})
+	fst.NoError(t, err)
+	fst.NoError(t, os.MkdirAll(filepath.Join(dir, "bin"), 0755))
+	ctx := context.Background()
+
+	// 没有满足要求的引导版本
+	fakeInstall(t, m, "go1.21.5")
+	_, err = m.Install(ctx, "gotip", ChannelAny)
+	fst.Error(t, err)
+	fst.True(t, strings.Contains(err.Error(), "go1.22.6"))
+
+	fakeInstall(t, m, "go1.23.1")
+	fst.NoError(t, os.WriteFile(filepath.Join(m.GOROOT(mustParseVersion(t, "go1.23.1")), unpackedOkay), nil, 0644))
+
+	sdk, err := m.Install(ctx, "gotip", ChannelAny)
+	fst.NoError(t, err)
+	fst.True(t, m.Installed(sdk.Version))
+	out, err := exec.Command(sdk.GoBin).Output()
+	fst.NoError(t, err)
+	fst.Equal(t, "devel 1", strings.TrimSpace(string(out)))
+	info, err := m.TipInfo()
+	fst.NoError(t, err)
+	fst.Equal(t, "master", info.Ref)
+	fst.Equal(t, gitRun(t, work, "rev-parse", "HEAD"), info.Commit)
+	_, err = os.Lstat(filepath.Join(dir, "bin", "gotip"))
+	fst.NoError(t, err)
+	fst.Equal(t, 1, builds())
+
+	// 没有新的提交，不会重新构建
+	_, err = m.InstallTip(ctx, "")
+	fst.NoError(t, err)
+	fst.Equal(t, 1, builds())
+
+	// 有新的提交
+	fst.NoError(t, os.WriteFile(filepath.Join(work, "VERSION"), []byte("devel 2"), 0644))
+	gitRun(t, work, "commit", "-am", "update")
+	gitRun(t, work, "push", "origin", "master")
+	_, err = m.InstallTip(ctx, "")
+	fst.NoError(t, err)
+	fst.Equal(t, 2, builds())
+	out, err = exec.Command(sdk.GoBin).Output()
+	fst.NoError(t, err)
+	fst.Equal(t, "devel 2", strings.TrimSpace(string(out)))
+
+	// 指定分支
+	gitRun(t, work, "checkout", "-b", "release-branch.go1.23")
+	fst.NoError(t, os.WriteFile(filepath.Join(work, "VERSION"), []byte("go1.23.9"), 0644))
+	gitRun(t, work, "commit", "-am", "release")
+	gitRun(t, work, "push", "origin", "release-branch.go1.23")
+	_, err = m.Install(ctx, "gotip@release-branch.go1.23", ChannelAny)
+	fst.NoError(t, err)
+	info, err = m.TipInfo()
+	fst.NoError(t, err)
+	fst.Equal(t, "release-branch.go1.23", info.Ref)
+	out, err = exec.Command(sdk.GoBin).Output()
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.23.9", strings.TrimSpace(string(out)))
+
+	// 指定提交
+	first := gitRun(t, work, "rev-list", "--max-parents=0", "HEAD")
+	_, err = m.Install(ctx, "gotip@"+first[:10], ChannelAny)
+	fst.NoError(t, err)
+	info, err = m.TipInfo()
+	fst.NoError(t, err)
+	fst.Equal(t, first, info.Commit)
+
+	_, err = m.Install(ctx, "gotip@no-such-branch", ChannelAny)
+	fst.Error(t, err)
+	// 失败时不影响已安装的 gotip
+	fst.True(t, m.Installed(sdk.Version))
+
+	// 不会遗留临时目录和锁文件
+	entries, err := filepath.Glob(filepath.Join(m.SDKDir(), ".*"))
+	fst.NoError(t, err)
+	fst.Empty(t, entries)
+}
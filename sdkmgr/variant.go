@@ -0,0 +1,295 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+package sdkmgr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// variantInfoFile 变体版本的构建信息，在其 GOROOT 下
+const variantInfoFile = ".smart-go-dl-variant.json"
+
+// BuildOptions Build 的参数
+type BuildOptions struct {
+	// Name 变体版本的名称，如 go1.22.5-acme，也可以只是变体名称，如 acme
+	Name string
+
+	// Patches 依次应用的补丁文件，如 git diff、git format-patch 的输出，路径相对于 GOROOT，如 a/src/runtime/proc.go
+	Patches []string
+}
+
+// VariantInfo 变体版本的构建信息
+type VariantInfo struct {
+	// Upstream 上游版本，如 go1.22.5
+	Upstream string `json:"upstream"`
+
+	// Patches 应用的补丁
+	Patches []VariantPatch `json:"patches"`
+
+	// Built 构建完成的时间
+	Built time.Time `json:"built"`
+}
+
+// VariantPatch 变体版本应用的一个补丁
+type VariantPatch struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+}
+
+// VariantInfo 已安装的变体版本的构建信息
+func (m *Manager) VariantInfo(v *Version) (*VariantInfo, error) {
+	bf, err := os.ReadFile(filepath.Join(m.installedGOROOT(v), variantInfoFile))
+	if err != nil {
+		return nil, err
+	}
+	info := &VariantInfo{}
+	if err = json.Unmarshal(bf, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// AllVersions 版本列表，以及已安装但不在版本列表中的版本，如变体版本、Adopt 接管的版本，
+// 变体版本按照 Normalized 单独分组，如 go1.22-acme
+func (m *Manager) AllVersions(ctx context.Context) (Versions, error) {
+	versions, err := m.Versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sdks, err := m.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	known := make(map[string]bool)
+	for _, v := range versions.All() {
+		names = append(names, v.Raw)
+		known[v.Raw] = true
+	}
+	var extra int
+	for _, s := range sdks {
+		if !known[s.Version.Raw] && !s.Version.IsTip() {
+			names = append(names, s.Version.Raw)
+			extra++
+		}
+	}
+	if extra == 0 {
+		return versions, nil
+	}
+	return ParseVersions(names), nil
+}
+
+// Build 给 version 打上补丁后构建，作为一个单独的变体版本安装，如 go1.22.5-acme
+//
+// 源码优先使用已安装的 version，否则下载其源码打包文件；补丁使用 git apply 应用，
+// GOROOT 下 VERSION 文件的版本号会改为变体版本的名称。
+// 变体版本有自己的 GOROOT 和 $GOBIN/go1.22.5-acme、$GOBIN/go1.22-acme，
+// 不会被 update、clean 当做上游版本的修订版本
+func (m *Manager) Build(ctx context.Context, version string, opts BuildOptions) (*SDK, error) {
+	if p := m.Platform(); p != CurrentPlatform() {
+		return nil, fmt.Errorf("variants can only be built for the current platform, not %s", p)
+	}
+	up, err := ParseVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	if up.IsTip() || up.IsLanguage() || up.IsVariant() {
+		return nil, fmt.Errorf("%q is not a release version, use one like go1.22.5", version)
+	}
+	name := opts.Name
+	if !strings.HasPrefix(name, "go") {
+		name = up.Name() + "-" + name
+	}
+	v, err := ParseVersion(name)
+	if err != nil || !v.IsVariant() {
+		return nil, fmt.Errorf("invalid variant name %q, expect one like %s-acme", opts.Name, up.Name())
+	}
+	if up.Name() != v.Upstream().Name() {
+		return nil, fmt.Errorf("variant %s is not based on %s", v.Raw, up.Raw)
+	}
+	if len(opts.Patches) == 0 {
+		return nil, errors.New("no patches to apply")
+	}
+	info := &VariantInfo{Upstream: up.Name()}
+	for _, p := range opts.Patches {
+		digest, err := fileSHA256(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		info.Patches = append(info.Patches, VariantPatch{Name: filepath.Base(p), SHA256: digest})
+	}
+
+	unlock := m.lockVersion(m.GOROOT(v))
+	defer unlock()
+	if err = os.MkdirAll(m.opts.SDKDir, 0755); err != nil {
+		return nil, err
+	}
+	unlockFile, err := lockFile(ctx, filepath.Join(m.opts.SDKDir, "."+v.Name()+".lock"))
+	if err != nil {
+		return nil, err
+	}
+	defer unlockFile()
+
+	staging := filepath.Join(m.opts.SDKDir, "."+v.Name()+".staging")
+	_ = os.RemoveAll(staging)
+	defer os.RemoveAll(staging)
+	root := filepath.Join(staging, "go")
+	if err = m.variantSource(ctx, up, staging, root); err != nil {
+		return nil, err
+	}
+	for _, p := range opts.Patches {
+		if err = m.applyPatch(ctx, root, p); err != nil {
+			return nil, err
+		}
+	}
+	if err = setVersionFile(root, v.Name()); err != nil {
+		return nil, err
+	}
+
+	minVer, err := MinBootstrap(up)
+	if err != nil {
+		return nil, err
+	}
+	bootstrap, err := m.findBootstrap(ctx, minVer)
+	if err != nil {
+		return nil, err
+	}
+	m.logPrint("build", v.Name(), "with", len(opts.Patches), "patches")
+	if err = m.makeBash(ctx, root, bootstrap); err != nil {
+		return nil, err
+	}
+
+	info.Built = time.Now()
+	bf, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(filepath.Join(root, variantInfoFile), bf, 0644); err != nil {
+		return nil, err
+	}
+	mf := &Manifest{Source: "build " + up.Name() + " with " + strings.Join(patchNames(info.Patches), ",")}
+	if err = m.installGOROOT(root, v, mf); err != nil {
+		return nil, err
+	}
+	return m.newSDK(v), m.linkVersion(ctx, v)
+}
+
+// variantSource 准备上游版本的源码到 root，已安装时复制其 GOROOT，否则获取源码打包文件
+func (m *Manager) variantSource(ctx context.Context, up *Version, staging string, root string) error {
+	if m.Unpacked(up) {
+		// 原地接管的版本 GOROOT 是软链
+		src, err := filepath.EvalSymlinks(m.installedGOROOT(up))
+		if err != nil {
+			return err
+		}
+		m.logPrint("build", "copy", src, "->", root)
+		return copyDir(ctx, src, root)
+	}
+	dlDir := filepath.Join(staging, "dl")
+	if err := os.MkdirAll(dlDir, 0755); err != nil {
+		return err
+	}
+	p := m.Platform()
+	req := &FetchRequest{
+		Version: up,
+		GOOS:    p.GOOS,
+		GOARCH:  p.GOARCH,
+		Source:  true,
+		Dir:     dlDir,
+	}
+	return m.fetch(ctx, req, func(ar *Archive) error {
+		_ = os.RemoveAll(root)
+		return m.unpackArchive(ctx, ar, root)
+	})
+}
+
+func patchNames(ps []VariantPatch) []string {
+	names := make([]string, 0, len(ps))
+	for _, p := range ps {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// applyPatch 在 root 中使用 git apply 应用补丁
+func (m *Manager) applyPatch(ctx context.Context, root string, patch string) error {
+	fp, err := filepath.Abs(patch)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "git", "apply", "--whitespace=nowarn", fp)
+	cmd.Dir = root
+	setCancel(cmd)
+	// root 不是 git 仓库，避免 SDKDir 在其他 git 仓库中时，git apply 忽略掉 root 之外的路径
+	cmd.Env = append(os.Environ(), "GIT_CEILING_DIRECTORIES="+filepath.Dir(root))
+	cmd.Stdout = m.output
+	cmd.Stderr = m.output
+	m.logPrint("exec", cmd.String())
+	if err = cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("apply %s failed: %w", patch, err)
+	}
+	return nil
+}
+
+// setVersionFile 修改 GOROOT/VERSION 第一行的版本号，go version 和 runtime.Version() 会使用它
+func setVersionFile(root string, name string) error {
+	fp := filepath.Join(root, "VERSION")
+	bf, err := os.ReadFile(fp)
+	if err != nil {
+		return err
+	}
+	_, rest, _ := strings.Cut(string(bf), "\n")
+	if len(rest) > 0 {
+		rest = "\n" + rest
+	}
+	return os.WriteFile(fp, []byte(name+rest), 0644)
+}
+
+// copyDir 复制目录，保留文件权限和软链
+func copyDir(ctx context.Context, src string, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		to := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(to, info.Mode().Perm()|0700)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, to)
+		case info.Mode().IsRegular():
+			return copyRegular(path, to, info.Mode().Perm())
+		default:
+			return nil
+		}
+	})
+}
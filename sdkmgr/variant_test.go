@@ -0,0 +1,147 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2026/10/19
+
+//go:build !windows
+
+package sdkmgr
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+const acmePatch = `diff --git a/src/runtime/proc.go b/src/runtime/proc.go
+--- a/src/runtime/proc.go
++++ b/src/runtime/proc.go
+@@ -1 +1,2 @@
+ package runtime
++// acme
+`
+
+// fakeSDK 模拟已安装的官方 SDK，包含源码和 make.bash
+func fakeSDK(t *testing.T, m *Manager, version string) {
+	t.Helper()
+	gr := m.GOROOT(mustParseVersion(t, version))
+	files := map[string]string{
+		"VERSION":             version + "\ntime 2024-07-02T20:00:00Z",
+		"bin/go":              "#!/bin/sh\n",
+		"src/make.bash":       fakeMakeBash,
+		"src/runtime/proc.go": "package runtime\n",
+		unpackedOkay:          "",
+		manifestFile:          "{}",
+	}
+	for name, content := range files {
+		fp := filepath.Join(gr, name)
+		fst.NoError(t, os.MkdirAll(filepath.Dir(fp), 0755))
+		fst.NoError(t, os.WriteFile(fp, []byte(content), 0755))
+	}
+}
+
+func TestManager_Build(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	t.Setenv("BUILD_LOG", filepath.Join(t.TempDir(), "build.log"))
+	t.Setenv("GOROOT_BOOTSTRAP", "")
+
+	dir := t.TempDir()
+	indexDir := filepath.Join(dir, "index")
+	fst.NoError(t, os.MkdirAll(indexDir, 0755))
+	for _, version := range []string{"go1.22.4", "go1.22.5"} {
+		fst.NoError(t, os.WriteFile(filepath.Join(indexDir, version+".linux-amd64.tar.gz"), nil, 0644))
+	}
+	shim := filepath.Join(dir, "smart-go-dl")
+	fst.NoError(t, os.WriteFile(shim, nil, 0755))
+	m, err := New(Options{
+		SDKDir:  filepath.Join(dir, "sdk"),
+		GOBIN:   filepath.Join(dir, "bin"),
+		Shim:    shim,
+		Indexes: []Index{&Dir{Path: indexDir}},
+	})
+	fst.NoError(t, err)
+	fst.NoError(t, os.MkdirAll(m.GOBIN(), 0755))
+	fakeSDK(t, m, "go1.22.4")
+	fakeSDK(t, m, "go1.22.5")
+
+	patch := filepath.Join(dir, "0001-acme.diff")
+	fst.NoError(t, os.WriteFile(patch, []byte(acmePatch), 0644))
+	ctx := context.Background()
+
+	_, err = m.Build(ctx, "go1.22.5", BuildOptions{Name: "go1.22.6-acme", Patches: []string{patch}})
+	fst.Error(t, err)
+	_, err = m.Build(ctx, "go1.22", BuildOptions{Name: "acme", Patches: []string{patch}})
+	fst.Error(t, err)
+
+	sdk, err := m.Build(ctx, "go1.22.5", BuildOptions{Name: "acme", Patches: []string{patch}})
+	fst.NoError(t, err)
+	v := sdk.Version
+	fst.Equal(t, "go1.22.5-acme", v.Raw)
+	fst.Equal(t, "go1.22-acme", v.Normalized)
+	fst.Equal(t, filepath.Join(m.SDKDir(), "go1.22.5-acme"), sdk.GOROOT)
+	fst.True(t, m.Unpacked(v))
+
+	bf, err := os.ReadFile(filepath.Join(sdk.GOROOT, "src", "runtime", "proc.go"))
+	fst.NoError(t, err)
+	fst.Equal(t, "package runtime\n// acme\n", string(bf))
+	out, err := exec.Command(sdk.GoBin).Output()
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.5-acme\ntime 2024-07-02T20:00:00Z", string(out))
+
+	// 上游版本不受影响
+	bf, err = os.ReadFile(filepath.Join(m.GOROOT(mustParseVersion(t, "go1.22.5")), "src", "runtime", "proc.go"))
+	fst.NoError(t, err)
+	fst.Equal(t, "package runtime\n", string(bf))
+
+	info, err := m.VariantInfo(v)
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.5", info.Upstream)
+	fst.Equal(t, 1, len(info.Patches))
+	fst.Equal(t, "0001-acme.diff", info.Patches[0].Name)
+
+	for _, name := range []string{"go1.22.5-acme", "go1.22-acme"} {
+		_, err = os.Lstat(filepath.Join(m.GOBIN(), name))
+		fst.NoError(t, err)
+	}
+
+	vs, err := m.AllVersions(ctx)
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.5-acme", vs.Get("go1.22-acme").Latest().Raw)
+	fst.Equal(t, 2, len(vs.Get("go1.22").PatchVersions))
+
+	got, err := m.Resolve(ctx, "go1.22.5")
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.5", got.Version.Raw)
+	got, err = m.Resolve(ctx, "stable")
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.5", got.Version.Raw)
+	got, err = m.Resolve(ctx, "go1.22-acme")
+	fst.NoError(t, err)
+	fst.Equal(t, "go1.22.5-acme", got.Version.Raw)
+
+	// 清理 go1.22 不会删除变体版本
+	fst.NoError(t, m.Clean(ctx, "go1.22", RemoveOptions{}))
+	fst.False(t, m.Installed(mustParseVersion(t, "go1.22.4")))
+	fst.True(t, m.Installed(v))
+
+	_, err = m.Install(ctx, "go1.22.5-acme", ChannelAny)
+	fst.Error(t, err)
+
+	// 补丁无法应用时，不会留下不完整的 GOROOT
+	bad := filepath.Join(dir, "bad.diff")
+	fst.NoError(t, os.WriteFile(bad, []byte(strings.ReplaceAll(acmePatch, " package runtime", " package main")), 0644))
+	_, err = m.Build(ctx, "go1.22.5", BuildOptions{Name: "bad", Patches: []string{bad}})
+	fst.Error(t, err)
+	fst.False(t, m.Installed(mustParseVersion(t, "go1.22.5-bad")))
+
+	fst.NoError(t, m.Remove(ctx, "go1.22.5-acme", RemoveOptions{}))
+	fst.False(t, m.Installed(v))
+	_, err = os.Lstat(filepath.Join(m.GOBIN(), "go1.22-acme"))
+	fst.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,201 @@
+// Copyright(C) 2021 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2021/12/31
+
+package sdkmgr
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/fsgo/smart-go-dl/goversion"
+)
+
+// Version go 版本信息，版本号的解析和比较见 goversion.Version
+//
+// GOROOT 目录、下载文件名都使用发布版本名称（见 Name），
+// $GOBIN 下的命令都使用 3 位版本号（见 RawFormatted），如 go1.20.0、go1.21.0
+type Version struct {
+	goversion.Version
+
+	// 归一化的二位版本号，如 go1.17，变体版本包含变体名称，如 go1.22.5-acme 为 go1.22-acme
+	Normalized string
+}
+
+// String 格式化输出
+func (v *Version) String() string {
+	bf, _ := json.Marshal(v)
+	return string(bf)
+}
+
+// RawFormatted 真实的 3 位版本号，如 go1.16.1
+// 若是 go1.16 这种第一个正式版本，会将其转换为 go1.16.0
+func (v *Version) RawFormatted() string {
+	return v.Formatted()
+}
+
+// Compare 和另外一个版本比较，v < b 时返回 -1，v == b 时返回 0，v > b 时返回 1
+func (v *Version) Compare(b *Version) int {
+	return v.Version.Compare(&b.Version)
+}
+
+// IsNormal 是否正式版本，即非 beta、rc
+func (v *Version) IsNormal() bool {
+	return !v.IsPre()
+}
+
+// ParseVersion 解析版本号，如 go1.22、go1.22.5、go1.26rc1、gotip
+func ParseVersion(version string) (*Version, error) {
+	gv, err := goversion.Parse(version)
+	if err != nil {
+		return nil, err
+	}
+	return newVersion(gv), nil
+}
+
+func newVersion(gv *goversion.Version) *Version {
+	v := &Version{
+		Version:    *gv,
+		Normalized: gv.Lang(),
+	}
+	// 变体版本作为单独的次要版本，不会和上游的版本一起更新、清理
+	if gv.IsVariant() {
+		v.Normalized += "-" + gv.Variant
+	}
+	return v
+}
+
+// gotipVersion gotip 的版本信息
+func gotipVersion() *Version {
+	return newVersion(goversion.MustParse(goversion.Tip))
+}
+
+// IsConstraint 是否版本约束，而不是如 go1.22、go1.22.5、gotip 这样的版本号
+func IsConstraint(version string) bool {
+	_, err := goversion.Parse(version)
+	return err != nil
+}
+
+// Channel 版本渠道，用于区分正式版本和预览版本
+type Channel int
+
+const (
+	// ChannelAny 不区分正式版本和预览版本
+	ChannelAny Channel = iota
+
+	// ChannelStable 正式版本，如 go1.22.5
+	ChannelStable
+
+	// ChannelPre 预览版本，如 go1.26beta1、go1.26rc1
+	ChannelPre
+)
+
+// Match 版本是否属于当前渠道
+func (ch Channel) Match(v *Version) bool {
+	switch ch {
+	case ChannelStable:
+		return v.IsNormal()
+	case ChannelPre:
+		return !v.IsNormal()
+	default:
+		return true
+	}
+}
+
+// String 渠道名称
+func (ch Channel) String() string {
+	switch ch {
+	case ChannelStable:
+		return "stable"
+	case ChannelPre:
+		return "pre"
+	default:
+		return "any"
+	}
+}
+
+// MinorVersion 次要版本信息
+type MinorVersion struct {
+	NormalizedVersion string
+	PatchVersions     []*Version
+}
+
+// Latest 最新的版本
+func (mv *MinorVersion) Latest() *Version {
+	return mv.PatchVersions[0]
+}
+
+// LatestOf 指定渠道的最新版本，若没有会返回 nil
+func (mv *MinorVersion) LatestOf(ch Channel) *Version {
+	for _, pv := range mv.PatchVersions {
+		if ch.Match(pv) {
+			return pv
+		}
+	}
+	return nil
+}
+
+// Versions 一系列版本号
+type Versions []*MinorVersion
+
+// Get 获取指定的次要版本
+// 参数 version需要是一个归一化的次要版本，如 go1.16
+func (vs Versions) Get(version string) *MinorVersion {
+	for _, mv := range vs {
+		if mv.NormalizedVersion == version {
+			return mv
+		}
+	}
+	return nil
+}
+
+// All 所有的版本
+func (vs Versions) All() []*Version {
+	var result []*Version
+	for _, mv := range vs {
+		result = append(result, mv.PatchVersions...)
+	}
+	return result
+}
+
+// ParseVersions 解析版本号列表，按照次要版本分组，并按照倒序输出
+// 解析错误的版本号会忽略掉，总是会包含 gotip
+func ParseVersions(vs []string) Versions {
+	versions := make(map[string][]*Version)
+	seen := make(map[string]bool, len(vs))
+	for _, name := range vs {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 || name == goversion.Tip || seen[name] {
+			continue
+		}
+		seen[name] = true
+		vv, err := ParseVersion(name)
+		if err != nil {
+			continue
+		}
+		versions[vv.Normalized] = append(versions[vv.Normalized], vv)
+	}
+
+	versions[goversion.Tip] = []*Version{gotipVersion()}
+
+	var result Versions
+	for v, list := range versions {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Compare(list[j]) > 0
+		})
+		mv := &MinorVersion{
+			NormalizedVersion: v,
+			PatchVersions:     list,
+		}
+		result = append(result, mv)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a := result[i]
+		b := result[j]
+		return a.Latest().Compare(b.Latest()) > 0
+	})
+
+	return result
+}
@@ -0,0 +1,54 @@
+// Copyright(C) 2021 github.com/fsgo  All Rights Reserved.
+// Author: fsgo
+// Date: 2021/12/31
+
+package sdkmgr
+
+import (
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestParseVersions(t *testing.T) {
+	versions := []string{
+		"go1.1",
+		"go1.10", "go1.10.1", "go1.10.11",
+		"go1.7beta2", "go1.7rc1",
+		"go1.9beta2", "go1.9rc1", "go1.9rc2", "go1.9",
+		"go1.8beta1",
+		"go1.18beta2", "go1.18rc1", "go1.18", "go1.18.1",
+		"gotip",
+		"go1.21.0", "go1.21.1",
+		"go1.22.0", "go1.22.1",
+		"go1.23rc1", "go1.23rc2",
+	}
+	vs := ParseVersions(versions)
+	var got []string
+	for _, item := range vs {
+		got = append(got, item.Latest().Raw)
+	}
+	want := []string{
+		"gotip",
+		"go1.23rc2", "go1.22.1", "go1.21.1",
+		"go1.18.1", "go1.10.11", "go1.9", "go1.8beta1", "go1.7rc1", "go1.1",
+	}
+	fst.Equal(t, want, got)
+}
+
+func TestMinorVersion_LatestOf(t *testing.T) {
+	vs := ParseVersions([]string{"go1.26beta1", "go1.26rc1", "go1.26rc2", "go1.27rc1", "go1.25", "go1.25.1"})
+
+	latest := func(version string, ch Channel) string {
+		if v := vs.Get(version).LatestOf(ch); v != nil {
+			return v.Raw
+		}
+		return ""
+	}
+	fst.Equal(t, "go1.26rc2", latest("go1.26", ChannelAny))
+	fst.Equal(t, "go1.26rc2", latest("go1.26", ChannelPre))
+	fst.Equal(t, "", latest("go1.26", ChannelStable))
+	fst.Equal(t, "go1.25.1", latest("go1.25", ChannelStable))
+	fst.Equal(t, "", latest("go1.25", ChannelPre))
+	fst.Equal(t, "go1.27rc1", latest("go1.27", ChannelAny))
+}